@@ -7,38 +7,16 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/chatwoot/chatwoot-cli/internal/cmd"
-	"github.com/chatwoot/chatwoot-cli/internal/config"
-	"github.com/chatwoot/chatwoot-cli/internal/sdk"
-	"github.com/chatwoot/chatwoot-cli/internal/tui"
 	"github.com/willabides/kongplete"
 )
 
 var version = "dev"
 
 func main() {
-	// No args → launch interactive TUI
-	if len(os.Args) == 1 {
-		cfg, err := config.Load()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-		if cfg == nil || !cfg.IsValid() {
-			fmt.Fprintln(os.Stderr, "Not authenticated. Run: chatwoot auth login")
-			os.Exit(1)
-		}
-		client := sdk.NewClient(cfg.BaseURL, cfg.APIKey, cfg.AccountID)
-		if err := tui.Run(client, cfg.AccountID, version); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-		return
-	}
-
 	var cli cmd.CLI
 	parser := kong.Must(&cli,
 		kong.Name("chatwoot"),
-		kong.Description("Read-only CLI for Chatwoot."),
+		kong.Description("CLI and terminal UI for Chatwoot."),
 		kong.Vars{"version": version},
 		kong.UsageOnError(),
 	)
@@ -53,6 +31,11 @@ func main() {
 	cmdStr := ctx.Command()
 	skipAuth := strings.HasPrefix(cmdStr, "auth") ||
 		strings.HasPrefix(cmdStr, "config") ||
+		strings.HasPrefix(cmdStr, "webhook") ||
+		strings.HasPrefix(cmdStr, "view list") ||
+		strings.HasPrefix(cmdStr, "view show") ||
+		strings.HasPrefix(cmdStr, "view create") ||
+		(strings.HasPrefix(cmdStr, "view delete") && !cli.View.Delete.Remote) ||
 		strings.HasPrefix(cmdStr, "install-completions")
 
 	app, err := cmd.NewApp(&cli, skipAuth)
@@ -60,6 +43,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	app.Version = version
 
 	if err := ctx.Run(app); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -9,7 +9,9 @@ type AgentCmd struct {
 	List AgentListCmd `cmd:"" default:"1" help:"List agents."`
 }
 
-type AgentListCmd struct{}
+type AgentListCmd struct {
+	Available bool `help:"Only show agents who are online."`
+}
 
 func (c *AgentListCmd) Run(app *App) error {
 	agents, err := app.Client.Agents().List()
@@ -17,6 +19,16 @@ func (c *AgentListCmd) Run(app *App) error {
 		return err
 	}
 
+	if c.Available {
+		online := agents[:0]
+		for _, agent := range agents {
+			if agent.AvailabilityStatus == "online" {
+				online = append(online, agent)
+			}
+		}
+		agents = online
+	}
+
 	if app.Printer.Format == "json" && !app.Printer.Quiet {
 		app.Printer.PrintJSON(agents)
 		return nil
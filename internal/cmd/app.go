@@ -6,6 +6,7 @@ import (
 	"github.com/chatwoot/chatwoot-cli/internal/config"
 	"github.com/chatwoot/chatwoot-cli/internal/output"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/timefmt"
 )
 
 // App holds shared state passed to every command's Run method.
@@ -13,6 +14,8 @@ type App struct {
 	Client  *sdk.Client
 	Printer *output.Printer
 	Config  *config.Config
+	Time    *timefmt.Formatter
+	Version string
 }
 
 // NewApp creates an App from the parsed CLI flags.
@@ -21,7 +24,14 @@ func NewApp(cli *CLI, skipAuth bool) (*App, error) {
 	printer := output.NewPrinter(cli.Output, cli.NoColor, cli.Quiet)
 
 	if skipAuth {
-		return &App{Printer: printer}, nil
+		// Only for the time settings; these commands must also work with
+		// a missing or broken config, e.g. to fix it
+		cfg, _ := config.Load()
+		tf, err := newTimeFormatter(cli, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &App{Printer: printer, Time: tf}, nil
 	}
 
 	cfg, err := config.Load()
@@ -37,11 +47,32 @@ func NewApp(cli *CLI, skipAuth bool) (*App, error) {
 		cfg.AccountID = cli.Account
 	}
 
+	tf, err := newTimeFormatter(cli, cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	client := sdk.NewClient(cfg.BaseURL, cfg.APIKey, cfg.AccountID)
 
 	return &App{
 		Client:  client,
 		Printer: printer,
 		Config:  cfg,
+		Time:    tf,
 	}, nil
 }
+
+// newTimeFormatter resolves the timestamp style and timezone.
+// Flags win over config; config wins over the local defaults.
+func newTimeFormatter(cli *CLI, cfg *config.Config) (*timefmt.Formatter, error) {
+	style, tz := cli.TimeFormat, cli.TZ
+	if cfg != nil {
+		if style == "" {
+			style = cfg.TimeFormat
+		}
+		if tz == "" {
+			tz = cfg.Timezone
+		}
+	}
+	return timefmt.New(style, tz)
+}
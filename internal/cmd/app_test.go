@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chatwoot/chatwoot-cli/internal/timefmt"
+)
+
+func TestNewAppTimeSettingsWithoutAuth(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string // empty: no config file
+		cli       CLI
+		wantStyle timefmt.Style
+		wantZone  string
+	}{
+		{"no config", "", CLI{}, timefmt.Local, "Local"},
+		{"config", "time_format: iso\ntimezone: Asia/Tokyo\n", CLI{}, timefmt.ISO, "Asia/Tokyo"},
+		{"flags win", "time_format: iso\ntimezone: Asia/Tokyo\n", CLI{TimeFormat: "unix", TZ: "UTC"}, timefmt.Unix, "UTC"},
+		{"broken config", "time_format: [", CLI{}, timefmt.Local, "Local"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			if tt.config != "" {
+				dir := filepath.Join(home, ".chatwoot")
+				if err := os.MkdirAll(dir, 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.config), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			app, err := NewApp(&tt.cli, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if app.Time.Style != tt.wantStyle || app.Time.Location.String() != tt.wantZone {
+				t.Errorf("got %s in %s, want %s in %s", app.Time.Style, app.Time.Location, tt.wantStyle, tt.wantZone)
+			}
+		})
+	}
+}
@@ -7,20 +7,29 @@ import (
 
 // CLI is the root Kong struct defining the entire command tree.
 type CLI struct {
-	Output  string `short:"o" default:"text" enum:"text,json,csv" help:"Output format."`
+	Output  string `short:"o" default:"text" enum:"text,json,ndjson,csv" help:"Output format."`
 	Account int    `short:"a" help:"Override account ID."`
 	Quiet   bool   `short:"q" help:"Print only IDs."`
 	NoColor bool   `help:"Disable colored output."`
 	Verbose bool   `short:"v" help:"Show request/response details."`
 
+	TimeFormat string `name:"time-format" help:"Timestamp style: relative, iso, local, unix (default from config, else local)."`
+	TZ         string `name:"tz" help:"Timezone for timestamps, e.g. UTC or America/New_York (default from config, else system)."`
+
+	TUI          TUICmd                     `cmd:"" name:"tui" default:"withargs" help:"Open the interactive terminal UI (default)."`
 	Conversation ConversationCmd            `cmd:"" aliases:"conv" help:"List and view conversations."`
 	Message      MessageCmd                 `cmd:"" aliases:"msg" help:"View messages in a conversation."`
 	Contact      ContactCmd                 `cmd:"" help:"View and search contacts."`
 	Inbox        InboxCmd                   `cmd:"" help:"List and view inboxes."`
 	Agent        AgentCmd                   `cmd:"" help:"List agents."`
-	Profile      ProfileCmd                 `cmd:"" help:"Show your profile."`
+	Search       SearchCmd                  `cmd:"" help:"Search conversations and messages."`
+	View         ViewCmd                    `cmd:"" help:"Manage saved conversation views."`
+	Notification NotificationCmd            `cmd:"" aliases:"notif" help:"List and manage your notifications."`
+	Profile      ProfileCmd                 `cmd:"" help:"Show your profile and set your availability."`
 	Auth         AuthCmd                    `cmd:"" help:"Login, logout, and status."`
 	Config       ConfigCmd                  `cmd:"" aliases:"cfg" help:"Manage CLI configuration."`
+	Webhook      WebhookCmd                 `cmd:"" help:"Receive Chatwoot webhooks locally."`
+	Daemon       DaemonCmd                  `cmd:"" help:"Run hooks on new messages, assignments and SLA warnings."`
 	InstallCompletions kongplete.InstallCompletions `cmd:"" help:"Install shell completions."`
 
 	Version kong.VersionFlag `help:"Show version."`
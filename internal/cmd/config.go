@@ -43,6 +43,10 @@ func (c *ConfigViewCmd) Run(app *App) error {
 		{Key: "Base URL", Value: cfg.BaseURL},
 		{Key: "API Key", Value: maskedKey},
 		{Key: "Account ID", Value: fmt.Sprintf("%d", cfg.AccountID)},
+		{Key: "Time Format", Value: valueOrDefault(cfg.TimeFormat, "local")},
+		{Key: "Timezone", Value: valueOrDefault(cfg.Timezone, "system")},
+		{Key: "Theme", Value: valueOrDefault(cfg.Theme, "auto")},
+		{Key: "Mouse", Value: fmt.Sprintf("%t", cfg.Mouse)},
 	})
 
 	return nil
@@ -54,3 +58,10 @@ func maskAPIKey(key string) string {
 	}
 	return key[:4] + strings.Repeat("*", len(key)-8) + key[len(key)-4:]
 }
+
+func valueOrDefault(v, def string) string {
+	if v == "" {
+		return def + " (default)"
+	}
+	return v
+}
@@ -73,8 +73,8 @@ func (c *ContactViewCmd) Run(app *App) error {
 		{Key: "Phone", Value: contact.PhoneNumber},
 		{Key: "Company", Value: contact.CompanyName},
 		{Key: "Conversations", Value: strconv.Itoa(contact.ConversationsCount)},
-		{Key: "Last Activity", Value: formatTimestamp(contact.LastActivityAt)},
-		{Key: "Created", Value: formatTimestamp(contact.CreatedAt)},
+		{Key: "Last Activity", Value: app.Time.Format(contact.LastActivityAt)},
+		{Key: "Created", Value: app.Time.Format(contact.CreatedAt)},
 	})
 
 	return nil
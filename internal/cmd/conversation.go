@@ -2,16 +2,28 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/alecthomas/kong"
+	"github.com/chatwoot/chatwoot-cli/internal/export"
+	"github.com/chatwoot/chatwoot-cli/internal/notify"
 	"github.com/chatwoot/chatwoot-cli/internal/output"
+	"github.com/chatwoot/chatwoot-cli/internal/query"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
+	"github.com/chatwoot/chatwoot-cli/internal/watch"
 )
 
 type ConversationCmd struct {
-	List ConversationListCmd `cmd:"" default:"1" help:"List conversations."`
-	View ConversationViewCmd `cmd:"" help:"View a conversation."`
+	List     ConversationListCmd     `cmd:"" default:"1" help:"List conversations."`
+	View     ConversationViewCmd     `cmd:"" help:"View a conversation."`
+	Filter   ConversationFilterCmd   `cmd:"" help:"List conversations matching a filter query."`
+	Export   ConversationExportCmd   `cmd:"" help:"Export a full conversation transcript to a file."`
+	Priority ConversationPriorityCmd `cmd:"" help:"Set a conversation's priority."`
 }
 
 type ConversationListCmd struct {
@@ -21,10 +33,59 @@ type ConversationListCmd struct {
 	Team     int      `help:"Filter by team ID."`
 	Label    []string `short:"l" help:"Filter by labels."`
 	Sort     string   `default:"latest" help:"Sort: latest, created_at, priority."`
+	Priority []string `enum:"urgent,high,medium,low,none" help:"Only show these priorities (urgent, high, medium, low, none). Applied to the fetched page."`
+	View     string   `help:"Use a saved view instead of the filter flags (see 'chatwoot view')."`
 	Page     int      `short:"p" default:"1" help:"Page number."`
+
+	WatchFlags `embed:""`
+	Notify     bool `help:"With --watch, notify on new assignments and incoming messages (see notifications in config)."`
+
+	view     *views.View // resolved --view, loaded on first fetch
+	viewUser int
 }
 
-func (c *ConversationListCmd) Run(app *App) error {
+// viewReplaces lists the flags a saved view sets itself.
+var viewReplaces = []string{"status", "inbox", "assignee", "team", "label", "sort", "priority"}
+
+func (c *ConversationListCmd) Run(app *App, kctx *kong.Context) error {
+	if c.View != "" {
+		// Several of these have defaults, so only the parse path tells
+		// whether they were given
+		for _, p := range kctx.Path {
+			if p.Flag != nil && slices.Contains(viewReplaces, p.Flag.Name) {
+				return fmt.Errorf("--%s can't be used with --view; the view sets its own filters", p.Flag.Name)
+			}
+		}
+	}
+	if c.Watch {
+		return c.watch(app)
+	}
+
+	resp, err := c.fetch(app)
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(resp)
+		return nil
+	}
+	if app.Printer.Format == "ndjson" && !app.Printer.Quiet {
+		for _, conv := range resp.Data.Payload {
+			app.Printer.PrintNDJSON(conv)
+		}
+		return nil
+	}
+
+	printConversations(app, resp.Data.Payload)
+	return nil
+}
+
+func (c *ConversationListCmd) fetch(app *App) (*sdk.ConversationsListResponse, error) {
+	if c.View != "" {
+		return c.fetchView(app)
+	}
+
 	resp, err := app.Client.Conversations().List(sdk.ListOptions{
 		Status:       c.Status,
 		InboxID:      c.Inbox,
@@ -34,19 +95,120 @@ func (c *ConversationListCmd) Run(app *App) error {
 		SortBy:       c.Sort,
 		Page:         c.Page,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The list endpoint has no priority filter, so narrow the page here
+	if len(c.Priority) > 0 {
+		kept := resp.Data.Payload[:0]
+		for _, conv := range resp.Data.Payload {
+			priority := "none"
+			if conv.Priority != nil {
+				priority = *conv.Priority
+			}
+			if slices.Contains(c.Priority, priority) {
+				kept = append(kept, conv)
+			}
+		}
+		resp.Data.Payload = kept
+	}
+	return resp, nil
+}
+
+func (c *ConversationListCmd) loadView(app *App) error {
+	if c.view != nil {
+		return nil
+	}
+	v, userID, err := loadView(app, c.View)
 	if err != nil {
 		return err
 	}
+	c.view, c.viewUser = v, userID
+	return nil
+}
 
-	if app.Printer.Format == "json" && !app.Printer.Quiet {
-		app.Printer.PrintJSON(resp)
+func (c *ConversationListCmd) fetchView(app *App) (*sdk.ConversationsListResponse, error) {
+	if err := c.loadView(app); err != nil {
+		return nil, err
+	}
+
+	convos, _, err := views.Fetch(app.Client, *c.view, c.Page, c.viewUser)
+	if err != nil {
+		return nil, err
+	}
+	var resp sdk.ConversationsListResponse
+	resp.Data.Payload = convos
+	return &resp, nil
+}
+
+func (c *ConversationListCmd) watch(app *App) error {
+	tracker := watch.Conversations()
+	alerts, err := c.notifier(app)
+	if err != nil {
+		return err
+	}
+
+	return watchLoop(c.Interval, func() error {
+		resp, err := c.fetch(app)
+		if err != nil {
+			return err
+		}
+
+		convos := resp.Data.Payload
+		diff := tracker.Update(convos)
+		alerts(convos)
+		if liveRedraw(app) {
+			redraw(app, c.Interval, func() { printConversations(app, convos) })
+			return nil
+		}
+		for _, conv := range diff.Updated() {
+			emitRecord(app, conv.ID, conv)
+		}
 		return nil
+	})
+}
+
+// notifier returns a function that sends notifications for each poll when
+// --notify is set or config enables notifications for this filter.
+func (c *ConversationListCmd) notifier(app *App) (func([]sdk.Conversation), error) {
+	n, err := notify.New(app.Config.Notifications, os.Stderr, app.Time.Location)
+	if err != nil {
+		return nil, err
+	}
+	assignee := c.Assignee
+	if c.View != "" {
+		if err := c.loadView(app); err != nil {
+			return nil, err
+		}
+		assignee = c.view.AssigneeOrDefault()
+	}
+	if c.Notify {
+		n.Enable(assignee)
+	}
+	if !n.Enabled(assignee) {
+		return func([]sdk.Conversation) {}, nil
 	}
 
-	convos := resp.Data.Payload
+	profile, err := app.Client.Profile().Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	detector := notify.NewDetector(profile.ID)
+
+	return func(convos []sdk.Conversation) {
+		for _, note := range detector.Update(convos) {
+			if err := n.Notify(assignee, note); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: notification failed: %v\n", err)
+			}
+		}
+	}, nil
+}
+
+func printConversations(app *App, convos []sdk.Conversation) {
 	if len(convos) == 0 {
-		fmt.Println("No conversations found.")
-		return nil
+		fmt.Fprintln(app.Printer.Writer, "No conversations found.")
+		return
 	}
 
 	headers := []string{"ID", "Status", "Contact", "Assignee", "Inbox", "Labels", "Last Activity"}
@@ -68,7 +230,7 @@ func (c *ConversationListCmd) Run(app *App) error {
 		if len(conv.Labels) > 0 {
 			labels = fmt.Sprintf("%v", conv.Labels)
 		}
-		lastActivity := formatTimestamp(conv.LastActivityAt)
+		lastActivity := app.Time.Format(conv.LastActivityAt)
 
 		rows = append(rows, []string{
 			strconv.Itoa(conv.ID),
@@ -82,11 +244,64 @@ func (c *ConversationListCmd) Run(app *App) error {
 	}
 
 	app.Printer.PrintTable(headers, rows)
+}
+
+type ConversationFilterCmd struct {
+	Query   []string `arg:"" help:"Filter query, e.g. 'status=open and priority in (high,urgent) and created_at>7d'."`
+	Page    int      `short:"p" default:"1" help:"Page number."`
+	Explain bool     `help:"Print the filter payload sent to the API instead of running it."`
+}
+
+func (c *ConversationFilterCmd) Run(app *App) error {
+	filter, err := query.Parse(strings.Join(c.Query, " "), time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	// Let "assignee=me" stand in for the current agent's ID
+	for _, pred := range filter.Predicates() {
+		if pred.AttributeKey != "assignee_id" || !slices.Contains(pred.Values, "me") {
+			continue
+		}
+		profile, err := app.Client.Profile().Get()
+		if err != nil {
+			return fmt.Errorf("failed to get profile: %w", err)
+		}
+		for i, v := range pred.Values {
+			if v == "me" {
+				pred.Values[i] = strconv.Itoa(profile.ID)
+			}
+		}
+	}
+
+	if c.Explain {
+		app.Printer.PrintJSON(sdk.FilterRequest{Payload: filter.Predicates()})
+		return nil
+	}
+
+	resp, err := app.Client.Conversations().Filter(filter, c.Page)
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(resp)
+		return nil
+	}
+	if app.Printer.Format == "ndjson" && !app.Printer.Quiet {
+		for _, conv := range resp.Payload {
+			app.Printer.PrintNDJSON(conv)
+		}
+		return nil
+	}
+
+	printConversations(app, resp.Payload)
 	return nil
 }
 
 type ConversationViewCmd struct {
-	ID int `arg:"" help:"Conversation ID."`
+	ID       int `arg:"" help:"Conversation ID."`
+	Messages int `short:"n" default:"10" help:"Number of recent messages to show (0 to hide)."`
 }
 
 func (c *ConversationViewCmd) Run(app *App) error {
@@ -95,6 +310,14 @@ func (c *ConversationViewCmd) Run(app *App) error {
 		return err
 	}
 
+	if c.Messages > 0 {
+		messages, err := app.Client.Messages(c.ID).Latest(c.Messages)
+		if err != nil {
+			return err
+		}
+		conv.Messages = messages
+	}
+
 	if app.Printer.Format == "json" && !app.Printer.Quiet {
 		app.Printer.PrintJSON(conv)
 		return nil
@@ -134,16 +357,107 @@ func (c *ConversationViewCmd) Run(app *App) error {
 		{Key: "Channel", Value: conv.Meta.Channel},
 		{Key: "Labels", Value: labels},
 		{Key: "Messages", Value: strconv.Itoa(conv.MessagesCount)},
-		{Key: "Created", Value: formatTimestamp(conv.CreatedAt)},
-		{Key: "Last Activity", Value: formatTimestamp(conv.LastActivityAt)},
+		{Key: "Created", Value: app.Time.Format(conv.CreatedAt)},
+		{Key: "Last Activity", Value: app.Time.Format(conv.LastActivityAt)},
 	})
 
+	if app.Printer.Format != "text" || app.Printer.Quiet || len(conv.Messages) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(app.Printer.Writer)
+	if conv.MessagesCount > len(conv.Messages) {
+		fmt.Fprintf(app.Printer.Writer, "Messages (latest %d of %d)\n\n", len(conv.Messages), conv.MessagesCount)
+	} else {
+		fmt.Fprintf(app.Printer.Writer, "Messages\n\n")
+	}
+	newTranscript(app).Write(conv.Messages)
+
 	return nil
 }
 
-func formatTimestamp(ts int64) string {
-	if ts == 0 {
-		return ""
+type ConversationPriorityCmd struct {
+	ID       int    `arg:"" help:"Conversation ID."`
+	Priority string `arg:"" enum:"urgent,high,medium,low,none" help:"Priority: urgent, high, medium, low, none."`
+}
+
+func (c *ConversationPriorityCmd) Run(app *App) error {
+	if err := app.Client.Conversations().TogglePriority(c.ID, c.Priority); err != nil {
+		return err
 	}
-	return time.Unix(ts, 0).Format("2006-01-02 15:04")
+	if !app.Printer.Quiet {
+		fmt.Fprintf(app.Printer.Writer, "Set priority of conversation #%d to %s\n", c.ID, c.Priority)
+	}
+	return nil
+}
+
+type ConversationExportCmd struct {
+	ID          int    `arg:"" help:"Conversation ID."`
+	Format      string `short:"f" default:"md" enum:"md,html,txt,json" help:"Export format: md, html, txt, json."`
+	File        string `name:"file" placeholder:"PATH" help:"Output file ('-' for stdout). Default: conversation-<id>.<format>."`
+	NoPrivate   bool   `help:"Exclude private notes."`
+	EmbedImages bool   `help:"Inline image attachments as data URIs (html only)."`
+}
+
+func (c *ConversationExportCmd) Run(app *App) error {
+	conv, err := app.Client.Conversations().Get(c.ID)
+	if err != nil {
+		return err
+	}
+
+	messages, err := app.Client.Messages(c.ID).Latest(0)
+	if err != nil {
+		return err
+	}
+	if c.NoPrivate {
+		messages = export.FilterPrivate(messages)
+	}
+
+	var contact *sdk.ContactFull
+	if conv.Meta.Sender != nil {
+		contact, err = app.Client.Contacts().Get(conv.Meta.Sender.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	transcript := &export.Transcript{
+		Conversation: conv,
+		Contact:      contact,
+		Messages:     messages,
+		URL:          fmt.Sprintf("%s/app/accounts/%d/conversations/%d", app.Client.BaseURL, app.Client.AccountID, conv.ID),
+		ExportedAt:   time.Now(),
+	}
+	opts := export.Options{Time: app.Time, EmbedImages: c.EmbedImages}
+
+	if c.File == "-" {
+		return export.Write(app.Printer.Writer, c.Format, transcript, opts)
+	}
+
+	path := c.File
+	if path == "" {
+		path = fmt.Sprintf("conversation-%d.%s", conv.ID, c.Format)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	err = export.Write(f, c.Format, transcript, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	switch {
+	case app.Printer.Quiet:
+		fmt.Fprintln(app.Printer.Writer, path)
+	case app.Printer.Format == "json":
+		app.Printer.PrintJSON(map[string]interface{}{"path": path, "format": c.Format, "messages": len(messages)})
+	default:
+		fmt.Fprintf(app.Printer.Writer, "Exported %d messages to %s\n", len(messages), path)
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/daemon"
+	"github.com/chatwoot/chatwoot-cli/internal/hooks"
+)
+
+type DaemonCmd struct {
+	Config string `short:"c" help:"Hooks file (default: ~/.chatwoot/hooks.yaml)."`
+	DryRun bool   `help:"Print events as NDJSON instead of running hooks."`
+	Once   bool   `help:"Poll once and exit."`
+}
+
+func (c *DaemonCmd) Run(app *App) error {
+	path := c.Config
+	if path == "" {
+		var err error
+		if path, err = hooks.ConfigPath(); err != nil {
+			return err
+		}
+	}
+	cfg, err := hooks.Load(path)
+	if err != nil {
+		return err
+	}
+
+	profile, err := app.Client.Profile().Get()
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	d := daemon.New(app.Client, cfg, profile.ID)
+	d.Log = os.Stderr
+	d.Emit = func(ctx context.Context, ev *hooks.Event) {
+		if c.DryRun {
+			app.Printer.PrintNDJSON(ev)
+			return
+		}
+		runHooks(ctx, cfg.Hooks, ev)
+	}
+
+	if c.Once {
+		d.Poll(ctx)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching %d filter(s) every %s with %d hook(s) · Ctrl+C to stop\n",
+		len(cfg.Watches), cfg.Interval, len(cfg.Hooks))
+	return d.Run(ctx)
+}
+
+// runHooks runs every hook matching ev in file order. Failures are logged
+// so one broken hook doesn't stop the others.
+func runHooks(ctx context.Context, hs []hooks.Hook, ev *hooks.Event) {
+	for _, h := range hs {
+		if !h.Matches(ev) {
+			continue
+		}
+		name := h.Name
+		if name == "" {
+			name = h.Command + h.URL
+		}
+		stamp := time.Now().Format("15:04:05")
+		if err := h.Run(ctx, ev); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s #%d → %s: %v\n", stamp, ev.Name, ev.Conversation.ID, name, err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s %s #%d → %s\n", stamp, ev.Name, ev.Conversation.ID, name)
+	}
+}
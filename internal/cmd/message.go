@@ -4,18 +4,29 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/watch"
 )
 
 type MessageCmd struct {
 	List MessageListCmd `cmd:"" help:"List messages in a conversation."`
+	Tail MessageTailCmd `cmd:"" help:"Print the latest messages, then follow new ones as they arrive."`
 }
 
 type MessageListCmd struct {
 	ConversationID int `arg:"" help:"Conversation ID."`
 	Before         int `help:"Messages before this message ID."`
+
+	WatchFlags `embed:""`
 }
 
 func (c *MessageListCmd) Run(app *App) error {
+	if c.Watch {
+		return c.watch(app)
+	}
+
 	resp, err := app.Client.Messages(c.ConversationID).List(c.Before)
 	if err != nil {
 		return err
@@ -25,11 +36,108 @@ func (c *MessageListCmd) Run(app *App) error {
 		app.Printer.PrintJSON(resp)
 		return nil
 	}
+	if app.Printer.Format == "ndjson" && !app.Printer.Quiet {
+		for _, msg := range resp.Payload {
+			app.Printer.PrintNDJSON(msg)
+		}
+		return nil
+	}
 
-	messages := resp.Payload
-	if len(messages) == 0 {
-		fmt.Println("No messages found.")
+	printMessages(app, resp.Payload)
+	return nil
+}
+
+func (c *MessageListCmd) watch(app *App) error {
+	tracker := watch.Messages()
+	return watchLoop(c.Interval, func() error {
+		resp, err := app.Client.Messages(c.ConversationID).List(c.Before)
+		if err != nil {
+			return err
+		}
+
+		diff := tracker.Update(resp.Payload)
+		if liveRedraw(app) {
+			redraw(app, c.Interval, func() { printMessages(app, resp.Payload) })
+			return nil
+		}
+		for _, msg := range diff.Updated() {
+			emitRecord(app, msg.ID, msg)
+		}
 		return nil
+	})
+}
+
+type MessageTailCmd struct {
+	ConversationID int           `arg:"" help:"Conversation ID."`
+	Lines          int           `short:"n" default:"10" help:"Number of recent messages to print first."`
+	Interval       time.Duration `default:"5s" help:"Polling interval for new messages."`
+}
+
+func (c *MessageTailCmd) Run(app *App) error {
+	svc := app.Client.Messages(c.ConversationID)
+	tail := &messageTail{app: app}
+
+	// Always fetch at least one message so the cursor starts at the newest,
+	// even when no backlog is requested
+	recent, err := svc.Latest(max(c.Lines, 1))
+	if err != nil {
+		return err
+	}
+	lastID := 0
+	for i, msg := range recent {
+		if i >= len(recent)-c.Lines {
+			tail.print(msg)
+		}
+		lastID = msg.ID
+	}
+
+	return watchLoop(c.Interval, func() error {
+		resp, err := svc.ListAfter(lastID)
+		if err != nil {
+			return err
+		}
+		for _, msg := range resp.Payload {
+			if msg.ID <= lastID {
+				continue
+			}
+			tail.print(msg)
+			lastID = msg.ID
+		}
+		return nil
+	})
+}
+
+// messageTail prints streamed messages: styled transcript entries in text
+// mode, one JSON object per line for json/ndjson, IDs in quiet mode.
+type messageTail struct {
+	app        *App
+	transcript *transcript
+	printed    int
+}
+
+func (t *messageTail) print(msg sdk.Message) {
+	p := t.app.Printer
+	switch {
+	case p.Quiet:
+		fmt.Fprintln(p.Writer, msg.ID)
+	case p.Format == "json" || p.Format == "ndjson":
+		p.PrintNDJSON(msg)
+	default:
+		if t.transcript == nil {
+			t.transcript = newTranscript(t.app)
+		}
+		if t.printed > 0 {
+			fmt.Fprintln(p.Writer)
+		}
+		t.transcript.WriteMessage(msg)
+	}
+	t.printed++
+}
+
+func printMessages(app *App, messages []sdk.Message) {
+	if len(messages) == 0 {
+		fmt.Fprintln(app.Printer.Writer, "No messages found.")
+		return
 	}
 
 	headers := []string{"ID", "Type", "Sender", "Content", "Time"}
@@ -44,7 +152,7 @@ func (c *MessageListCmd) Run(app *App) error {
 			msgType = "note"
 		}
 		content := truncate(strings.ReplaceAll(msg.Content, "\n", " "), 60)
-		ts := formatTimestamp(msg.CreatedAt)
+		ts := app.Time.Format(msg.CreatedAt)
 
 		rows = append(rows, []string{
 			strconv.Itoa(msg.ID),
@@ -56,7 +164,6 @@ func (c *MessageListCmd) Run(app *App) error {
 	}
 
 	app.Printer.PrintTable(headers, rows)
-	return nil
 }
 
 func messageTypeName(t int) string {
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type NotificationCmd struct {
+	List    NotificationListCmd    `cmd:"" default:"1" help:"List your notifications."`
+	Read    NotificationReadCmd    `cmd:"" help:"Mark notifications as read."`
+	ReadAll NotificationReadAllCmd `cmd:"" name:"read-all" help:"Mark all notifications as read."`
+	Count   NotificationCountCmd   `cmd:"" help:"Show the number of unread notifications."`
+}
+
+type NotificationListCmd struct {
+	Page   int  `short:"p" default:"1" help:"Page number."`
+	Unread bool `short:"u" help:"Only show unread notifications."`
+}
+
+func (c *NotificationListCmd) Run(app *App) error {
+	resp, err := app.Client.Notifications().List(c.Page)
+	if err != nil {
+		return err
+	}
+
+	notifications := resp.Data.Payload
+	if c.Unread {
+		unread := notifications[:0]
+		for _, n := range notifications {
+			if !n.IsRead() {
+				unread = append(unread, n)
+			}
+		}
+		notifications = unread
+		resp.Data.Payload = unread
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(resp)
+		return nil
+	}
+	if app.Printer.Format == "ndjson" && !app.Printer.Quiet {
+		for _, n := range notifications {
+			app.Printer.PrintNDJSON(n)
+		}
+		return nil
+	}
+
+	if len(notifications) == 0 {
+		fmt.Fprintln(app.Printer.Writer, "No notifications found.")
+		return nil
+	}
+
+	headers := []string{"ID", "Type", "Title", "Conversation", "Read", "Created"}
+	rows := make([][]string, 0, len(notifications))
+	for _, n := range notifications {
+		conv := ""
+		if id := n.ConversationID(); id != 0 {
+			conv = strconv.Itoa(id)
+		}
+		read := "no"
+		if n.IsRead() {
+			read = "yes"
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(n.ID),
+			n.NotificationType,
+			n.PushMessageTitle,
+			conv,
+			read,
+			app.Time.Format(n.CreatedAt),
+		})
+	}
+
+	app.Printer.PrintTable(headers, rows)
+	return nil
+}
+
+type NotificationReadCmd struct {
+	IDs []int `arg:"" name:"id" help:"Notification IDs."`
+}
+
+func (c *NotificationReadCmd) Run(app *App) error {
+	for _, id := range c.IDs {
+		if err := app.Client.Notifications().MarkRead(id); err != nil {
+			return err
+		}
+	}
+	if !app.Printer.Quiet {
+		fmt.Fprintf(app.Printer.Writer, "Marked %d notification(s) as read\n", len(c.IDs))
+	}
+	return nil
+}
+
+type NotificationReadAllCmd struct{}
+
+func (c *NotificationReadAllCmd) Run(app *App) error {
+	if err := app.Client.Notifications().MarkAllRead(); err != nil {
+		return err
+	}
+	if !app.Printer.Quiet {
+		fmt.Fprintln(app.Printer.Writer, "Marked all notifications as read")
+	}
+	return nil
+}
+
+type NotificationCountCmd struct{}
+
+func (c *NotificationCountCmd) Run(app *App) error {
+	count, err := app.Client.Notifications().UnreadCount()
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(map[string]int{"unread_count": count})
+		return nil
+	}
+	fmt.Fprintln(app.Printer.Writer, count)
+	return nil
+}
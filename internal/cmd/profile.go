@@ -1,14 +1,22 @@
 package cmd
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/chatwoot/chatwoot-cli/internal/output"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
 )
 
-type ProfileCmd struct{}
+type ProfileCmd struct {
+	Show   ProfileShowCmd   `cmd:"" default:"1" help:"Show your profile."`
+	Status ProfileStatusCmd `cmd:"" help:"Show or set your availability: online, busy, offline."`
+}
+
+type ProfileShowCmd struct{}
 
-func (c *ProfileCmd) Run(app *App) error {
+func (c *ProfileShowCmd) Run(app *App) error {
 	profile, err := app.Client.Profile().Get()
 	if err != nil {
 		return err
@@ -29,3 +37,40 @@ func (c *ProfileCmd) Run(app *App) error {
 
 	return nil
 }
+
+type ProfileStatusCmd struct {
+	Availability string `arg:"" optional:"" help:"New availability: online, busy, offline. Omit to show the current one."`
+}
+
+func (c *ProfileStatusCmd) Run(app *App) error {
+	if c.Availability == "" {
+		profile, err := app.Client.Profile().Get()
+		if err != nil {
+			return err
+		}
+		if app.Printer.Format == "json" && !app.Printer.Quiet {
+			app.Printer.PrintJSON(map[string]string{"availability_status": profile.AvailabilityStatus})
+			return nil
+		}
+		fmt.Fprintln(app.Printer.Writer, profile.AvailabilityStatus)
+		return nil
+	}
+
+	if !slices.Contains(sdk.AvailabilityStatuses, c.Availability) {
+		return fmt.Errorf("invalid availability %q (want online, busy or offline)", c.Availability)
+	}
+
+	profile, err := app.Client.Profile().SetAvailability(c.Availability)
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(profile)
+		return nil
+	}
+	if !app.Printer.Quiet {
+		fmt.Fprintf(app.Printer.Writer, "Availability set to %s\n", c.Availability)
+	}
+	return nil
+}
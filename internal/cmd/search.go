@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chatwoot/chatwoot-cli/internal/search"
+)
+
+type SearchCmd struct {
+	Query    []string `arg:"" help:"Text to search for in messages, contacts and conversation IDs."`
+	Page     int      `short:"p" default:"1" help:"Page number."`
+	Snippets int      `short:"n" default:"3" help:"Matching messages shown per conversation (0 for all)."`
+}
+
+func (c *SearchCmd) Run(app *App) error {
+	query := strings.Join(c.Query, " ")
+	results, err := search.Run(app.Client, query, c.Page)
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(results)
+		return nil
+	}
+	if app.Printer.Format == "ndjson" && !app.Printer.Quiet {
+		for _, r := range results {
+			app.Printer.PrintNDJSON(r)
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(app.Printer.Writer, "No results found.")
+		return nil
+	}
+
+	if app.Printer.Format == "csv" || app.Printer.Quiet {
+		headers := []string{"ID", "Contact", "Inbox", "Matches", "Snippet"}
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			snippet := ""
+			if len(r.Matches) > 0 {
+				snippet = r.Matches[0].Snippet
+			}
+			rows = append(rows, []string{
+				strconv.Itoa(r.ConversationID),
+				r.Contact,
+				r.Inbox,
+				strconv.Itoa(len(r.Matches)),
+				snippet,
+			})
+		}
+		app.Printer.PrintTable(headers, rows)
+		return nil
+	}
+
+	c.printText(app, results)
+	return nil
+}
+
+// printText lists each conversation with its matching messages below it,
+// the search term highlighted.
+func (c *SearchCmd) printText(app *App, results []search.Result) {
+	r := app.Printer.Renderer()
+	title := r.NewStyle().Bold(true)
+	muted := r.NewStyle().Foreground(transcriptMuted)
+	mark := r.NewStyle().Bold(true).Foreground(transcriptPrivate)
+
+	for i, res := range results {
+		if i > 0 {
+			fmt.Fprintln(app.Printer.Writer)
+		}
+
+		contact := res.Contact
+		if contact == "" {
+			contact = "Unknown"
+		}
+		details := []string{app.Time.Format(res.LastMatchAt)}
+		if res.Inbox != "" {
+			details = append([]string{res.Inbox}, details...)
+		}
+		if res.Assignee != "" {
+			details = append(details, "assigned to "+res.Assignee)
+		}
+		fmt.Fprintln(app.Printer.Writer,
+			title.Render(fmt.Sprintf("#%d %s", res.ConversationID, contact))+
+				muted.Render(" · "+strings.Join(details, " · ")))
+
+		matches := res.Matches
+		if c.Snippets > 0 && len(matches) > c.Snippets {
+			matches = matches[:c.Snippets]
+		}
+		for _, m := range matches {
+			sender := ""
+			if m.Sender != "" {
+				sender = muted.Render(m.Sender + ": ")
+			}
+			fmt.Fprintln(app.Printer.Writer, "  "+sender+search.Highlight(m, func(s string) string {
+				return mark.Render(s)
+			}))
+		}
+		if more := len(res.Matches) - len(matches); more > 0 {
+			fmt.Fprintln(app.Printer.Writer, muted.Render(fmt.Sprintf("  … %d more", more)))
+		}
+	}
+}
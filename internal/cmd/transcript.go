@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/markdown"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/timefmt"
+	"github.com/muesli/termenv"
+)
+
+// trailingPadRe matches trailing spaces, including ones wrapped in SGR codes.
+var trailingPadRe = regexp.MustCompile(`(\x1b\[[0-9;]*m +\x1b\[0m| )+$`)
+
+var (
+	transcriptAccent  = lipgloss.AdaptiveColor{Light: "#1a73e8", Dark: "#8ab4f8"}
+	transcriptMuted   = lipgloss.AdaptiveColor{Light: "#666666", Dark: "#888888"}
+	transcriptPrivate = lipgloss.AdaptiveColor{Light: "#b5851e", Dark: "#d4a72c"}
+)
+
+// transcript renders messages as a chat log for terminal output: a header
+// line per message, markdown-rendered content, then any attachments.
+type transcript struct {
+	w     io.Writer
+	time  *timefmt.Formatter
+	width int
+	md    *glamour.TermRenderer
+
+	incoming lipgloss.Style
+	outgoing lipgloss.Style
+	private  lipgloss.Style
+	muted    lipgloss.Style
+}
+
+func newTranscript(app *App) *transcript {
+	r := app.Printer.Renderer()
+
+	width := app.Printer.Width() - 4 // 2-column indent plus breathing room
+	if width < 20 {
+		width = 20
+	}
+
+	// Without colors, raw markdown reads better than glamour's ASCII style
+	var md *glamour.TermRenderer
+	if r.ColorProfile() != termenv.Ascii {
+		style := markdown.ChatStyle(markdown.BaseStyle(r.HasDarkBackground()))
+		md, _ = markdown.NewRenderer(style, width)
+	}
+
+	return &transcript{
+		w:        app.Printer.Writer,
+		time:     app.Time,
+		width:    width,
+		md:       md,
+		incoming: r.NewStyle().Bold(true),
+		outgoing: r.NewStyle().Bold(true).Foreground(transcriptAccent),
+		private:  r.NewStyle().Bold(true).Foreground(transcriptPrivate),
+		muted:    r.NewStyle().Foreground(transcriptMuted),
+	}
+}
+
+func (t *transcript) Write(msgs []sdk.Message) {
+	for i, msg := range msgs {
+		if i > 0 {
+			fmt.Fprintln(t.w)
+		}
+		t.WriteMessage(msg)
+	}
+}
+
+// WriteMessage renders a single message. Activity messages are a single
+// muted line; private notes get a colored bar so they stand out from replies.
+func (t *transcript) WriteMessage(msg sdk.Message) {
+	ts := t.time.Format(msg.CreatedAt)
+
+	if msg.MessageType == 2 {
+		content := strings.TrimSpace(msg.Content)
+		if content == "" {
+			content = "(activity)"
+		}
+		fmt.Fprintln(t.w, t.muted.Italic(true).Render("· "+content+" · "+ts))
+		return
+	}
+
+	sender := "Unknown"
+	if msg.Sender != nil && msg.Sender.Name != "" {
+		sender = msg.Sender.Name
+	}
+
+	nameStyle := t.incoming
+	if msg.MessageType == 1 {
+		nameStyle = t.outgoing
+	}
+	header := nameStyle.Render(sender) + t.muted.Render(fmt.Sprintf(" · %s · #%d", ts, msg.ID))
+	gutter := "  "
+	if msg.Private {
+		header = t.private.Render(sender+" [private note]") + t.muted.Render(fmt.Sprintf(" · %s · #%d", ts, msg.ID))
+		gutter = t.private.Render("┃") + " "
+	}
+	fmt.Fprintln(t.w, header)
+
+	for _, line := range strings.Split(t.renderContent(msg), "\n") {
+		fmt.Fprintln(t.w, gutter+line)
+	}
+
+	for _, att := range msg.Attachments {
+		fmt.Fprintln(t.w, gutter+t.muted.Render("📎 "+attachmentSummary(att)))
+	}
+}
+
+func (t *transcript) renderContent(msg sdk.Message) string {
+	content := strings.TrimSpace(msg.Content)
+	if content == "" {
+		if len(msg.Attachments) > 0 {
+			return t.muted.Render("(attachment)")
+		}
+		return t.muted.Render("(no content)")
+	}
+
+	if t.md == nil {
+		return markdown.PlainMentions(content)
+	}
+
+	rendered, err := t.md.Render(markdown.ReplaceMentions(content))
+	if err != nil {
+		return markdown.PlainMentions(content)
+	}
+	// glamour pads every line to the wrap width with styled spaces
+	lines := strings.Split(strings.Trim(rendered, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = trailingPadRe.ReplaceAllString(line, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func attachmentSummary(att sdk.Attachment) string {
+	parts := []string{att.FileType}
+	if att.FileSize > 0 {
+		parts = append(parts, formatBytes(att.FileSize))
+	}
+	parts = append(parts, att.DataURL)
+	return strings.Join(parts, " · ")
+}
+
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
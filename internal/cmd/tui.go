@@ -0,0 +1,15 @@
+package cmd
+
+import "github.com/chatwoot/chatwoot-cli/internal/tui"
+
+// TUICmd runs when no other command is given.
+type TUICmd struct {
+	Theme string `help:"Theme: auto, light, dark, high-contrast, solarized, a name in ~/.chatwoot/themes or a file path (default from config)."`
+}
+
+func (c *TUICmd) Run(app *App) error {
+	if c.Theme != "" {
+		app.Config.Theme = c.Theme
+	}
+	return tui.Run(app.Client, app.Config, app.Time, app.Version)
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chatwoot/chatwoot-cli/internal/output"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
+)
+
+type ViewCmd struct {
+	List   ViewListCmd   `cmd:"" default:"1" help:"List saved views."`
+	Show   ViewShowCmd   `cmd:"" help:"Show a saved view."`
+	Create ViewCreateCmd `cmd:"" help:"Save a view."`
+	Delete ViewDeleteCmd `cmd:"" help:"Delete a saved view."`
+	Sync   ViewSyncCmd   `cmd:"" help:"Sync views with Chatwoot's saved filters."`
+}
+
+type ViewListCmd struct{}
+
+func (c *ViewListCmd) Run(app *App) error {
+	all, err := views.Load()
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(all)
+		return nil
+	}
+
+	if len(all) == 0 {
+		fmt.Fprintln(app.Printer.Writer, "No saved views. Create one with `chatwoot view create`.")
+		return nil
+	}
+
+	headers := []string{"Name", "Status", "Assignee", "Filters", "Synced"}
+	rows := make([][]string, 0, len(all))
+	for _, v := range all {
+		synced := ""
+		if v.RemoteID != 0 {
+			synced = "#" + strconv.Itoa(v.RemoteID)
+		}
+		rows = append(rows, []string{v.Name, v.StatusOrDefault(), v.AssigneeOrDefault(), viewSummary(v), synced})
+	}
+
+	app.Printer.PrintTable(headers, rows)
+	return nil
+}
+
+type ViewShowCmd struct {
+	Name string `arg:"" help:"View name."`
+}
+
+func (c *ViewShowCmd) Run(app *App) error {
+	all, err := views.Load()
+	if err != nil {
+		return err
+	}
+	v, err := views.Find(all, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if app.Printer.Format == "json" && !app.Printer.Quiet {
+		app.Printer.PrintJSON(v)
+		return nil
+	}
+
+	sort := v.Sort
+	if sort == "" {
+		sort = "latest"
+	}
+	synced := "no"
+	if v.RemoteID != 0 {
+		synced = "yes (filter #" + strconv.Itoa(v.RemoteID) + ")"
+	}
+	app.Printer.PrintDetail([]output.KeyValue{
+		{Key: "Name", Value: v.Name},
+		{Key: "Status", Value: v.StatusOrDefault()},
+		{Key: "Assignee", Value: v.AssigneeOrDefault()},
+		{Key: "Inbox", Value: idOrEmpty(v.Inbox)},
+		{Key: "Team", Value: idOrEmpty(v.Team)},
+		{Key: "Labels", Value: strings.Join(v.Labels, ", ")},
+		{Key: "Priority", Value: strings.Join(v.Priority, ", ")},
+		{Key: "Sort", Value: sort},
+		{Key: "Query", Value: v.Query},
+		{Key: "Synced", Value: synced},
+	})
+	return nil
+}
+
+type ViewCreateCmd struct {
+	Name     string   `arg:"" help:"View name, used with --view and as the TUI tab label."`
+	Status   string   `short:"s" default:"open" help:"Status: open, resolved, pending, snoozed, all."`
+	Assignee string   `default:"all" enum:"me,unassigned,all" help:"Assignee: me, unassigned, all."`
+	Inbox    int      `short:"i" help:"Inbox ID."`
+	Team     int      `help:"Team ID."`
+	Label    []string `short:"l" help:"Labels (any of them matches)."`
+	Priority []string `enum:"urgent,high,medium,low,none" help:"Priorities: urgent, high, medium, low, none."`
+	Sort     string   `default:"latest" enum:"latest,created_at,priority" help:"Sort: latest, created_at, priority (ignored with --query)."`
+	Query    string   `help:"Filter query for anything else, e.g. 'created_at>7d' (see conversation filter)."`
+	Force    bool     `short:"f" help:"Replace an existing view with the same name."`
+}
+
+func (c *ViewCreateCmd) Run(app *App) error {
+	all, err := views.Load()
+	if err != nil {
+		return err
+	}
+
+	v := views.View{
+		Name:     c.Name,
+		Status:   c.Status,
+		Assignee: c.Assignee,
+		Inbox:    c.Inbox,
+		Team:     c.Team,
+		Labels:   c.Label,
+		Priority: c.Priority,
+		Sort:     c.Sort,
+		Query:    c.Query,
+	}
+	if v.Sort == "latest" {
+		v.Sort = ""
+	}
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range all {
+		if all[i].Name != c.Name {
+			continue
+		}
+		if !c.Force {
+			return fmt.Errorf("view %q already exists (use --force to replace it)", c.Name)
+		}
+		all[i] = v
+		replaced = true
+	}
+	if !replaced {
+		all = append(all, v)
+	}
+
+	if err := views.Save(all); err != nil {
+		return err
+	}
+	if !app.Printer.Quiet {
+		fmt.Fprintf(app.Printer.Writer, "Saved view %q\n", c.Name)
+	}
+	return nil
+}
+
+type ViewDeleteCmd struct {
+	Name   string `arg:"" help:"View name."`
+	Remote bool   `help:"Also delete the synced filter in Chatwoot."`
+}
+
+func (c *ViewDeleteCmd) Run(app *App) error {
+	all, err := views.Load()
+	if err != nil {
+		return err
+	}
+	v, err := views.Find(all, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if c.Remote && v.RemoteID != 0 {
+		if err := app.Client.CustomFilters().Delete(v.RemoteID); err != nil {
+			return err
+		}
+	}
+
+	kept := all[:0]
+	for _, view := range all {
+		if view.Name != c.Name {
+			kept = append(kept, view)
+		}
+	}
+	if err := views.Save(kept); err != nil {
+		return err
+	}
+	if !app.Printer.Quiet {
+		fmt.Fprintf(app.Printer.Writer, "Deleted view %q\n", c.Name)
+	}
+	return nil
+}
+
+type ViewSyncCmd struct {
+	Push bool `help:"Only upload local views."`
+	Pull bool `help:"Only download Chatwoot's saved filters."`
+}
+
+func (c *ViewSyncCmd) Run(app *App) error {
+	all, err := views.Load()
+	if err != nil {
+		return err
+	}
+	both := !c.Push && !c.Pull
+	out := app.Printer.Writer
+
+	if c.Pull || both {
+		var added []string
+		var skipped []views.Skipped
+		all, added, skipped, err = views.Pull(app.Client, all)
+		if err != nil {
+			return err
+		}
+		for _, name := range added {
+			fmt.Fprintf(out, "Pulled %q\n", name)
+		}
+		for _, s := range skipped {
+			fmt.Fprintf(out, "Skipped %q: %s\n", s.Name, s.Reason)
+		}
+	}
+
+	if c.Push || both {
+		profile, err := app.Client.Profile().Get()
+		if err != nil {
+			return fmt.Errorf("failed to get profile: %w", err)
+		}
+		pushed, err := views.Push(app.Client, all, profile.ID)
+		// Save whatever was linked before an error, so a retry doesn't
+		// create duplicates
+		if saveErr := views.Save(all); saveErr != nil && err == nil {
+			err = saveErr
+		}
+		for _, name := range pushed {
+			fmt.Fprintf(out, "Pushed %q\n", name)
+		}
+		return err
+	}
+
+	return views.Save(all)
+}
+
+// viewSummary describes a view's extra filters in one line.
+func viewSummary(v views.View) string {
+	var parts []string
+	if v.Inbox != 0 {
+		parts = append(parts, "inbox "+strconv.Itoa(v.Inbox))
+	}
+	if v.Team != 0 {
+		parts = append(parts, "team "+strconv.Itoa(v.Team))
+	}
+	if len(v.Labels) > 0 {
+		parts = append(parts, "labels "+strings.Join(v.Labels, ","))
+	}
+	if len(v.Priority) > 0 {
+		parts = append(parts, "priority "+strings.Join(v.Priority, ","))
+	}
+	if v.Query != "" {
+		parts = append(parts, v.Query)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func idOrEmpty(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// loadView finds a saved view by name, along with the current agent's ID
+// if the view needs it.
+func loadView(app *App, name string) (*views.View, int, error) {
+	all, err := views.Load()
+	if err != nil {
+		return nil, 0, err
+	}
+	v, err := views.Find(all, name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	userID := 0
+	if v.NeedsUser() {
+		profile, err := app.Client.Profile().Get()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get profile: %w", err)
+		}
+		userID = profile.ID
+	}
+	return v, userID, nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// WatchFlags adds --watch and --interval to list commands.
+type WatchFlags struct {
+	Watch    bool          `short:"w" help:"Keep polling until interrupted. Redraws on a terminal, otherwise streams new or changed records as NDJSON."`
+	Interval time.Duration `default:"30s" help:"Polling interval for --watch."`
+}
+
+// watchLoop calls poll immediately and then every interval until interrupted.
+// Errors after the first poll are reported and polling continues, so a flaky
+// connection doesn't end the session.
+func watchLoop(interval time.Duration, poll func() error) error {
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := poll(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := poll(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+	}
+}
+
+// liveRedraw reports whether watch mode should redraw the whole table in
+// place (interactive terminals) rather than stream changed records.
+func liveRedraw(app *App) bool {
+	p := app.Printer
+	return p.IsTerminal() && !p.Quiet && (p.Format == "text" || p.Format == "csv")
+}
+
+// redraw clears the screen, prints a status line, then calls draw.
+func redraw(app *App, interval time.Duration, draw func()) {
+	w := app.Printer.Writer
+	fmt.Fprint(w, "\x1b[H\x1b[2J")
+	fmt.Fprintf(w, "Every %s · updated %s · Ctrl+C to stop\n\n", interval, time.Now().Format("15:04:05"))
+	draw()
+}
+
+// emitRecord streams one new or changed record: its ID in quiet mode,
+// otherwise the full record as a line of JSON.
+func emitRecord(app *App, id int, record interface{}) {
+	if app.Printer.Quiet {
+		fmt.Fprintln(app.Printer.Writer, id)
+		return
+	}
+	app.Printer.PrintNDJSON(record)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/hooks"
+	"github.com/chatwoot/chatwoot-cli/internal/webhook"
+)
+
+type WebhookCmd struct {
+	Listen WebhookListenCmd `cmd:"" help:"Run a local server that receives Chatwoot webhooks."`
+}
+
+type WebhookListenCmd struct {
+	Port   int      `short:"p" default:"8080" help:"Port to listen on."`
+	Host   string   `default:"127.0.0.1" help:"Address to bind (use 0.0.0.0 to accept external traffic)."`
+	Path   string   `default:"/" help:"URL path that accepts deliveries."`
+	Secret string   `env:"CHATWOOT_WEBHOOK_SECRET" help:"Webhook secret. When set, deliveries without a valid signature are rejected."`
+	Event  []string `short:"e" help:"Only handle these events, e.g. message_created."`
+	Exec   string   `short:"x" help:"Shell command to run per event with the event JSON on stdin, instead of printing NDJSON."`
+}
+
+func (c *WebhookListenCmd) Run(app *App) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Handle events on one goroutine so output and hooks keep delivery order
+	// without holding up Chatwoot's request. Events queued when a signal
+	// arrives still run; a second signal kills the process.
+	events := make(chan *webhook.Event, 100)
+	var worker sync.WaitGroup
+	worker.Add(1)
+	go func() {
+		defer worker.Done()
+		for ev := range events {
+			c.handle(context.WithoutCancel(ctx), app, ev)
+		}
+	}()
+
+	// Shutdown gives up on slow requests after a timeout, so a handler can
+	// still be running when the queue closes; closed makes it drop the event.
+	var mu sync.Mutex
+	closed := false
+
+	mux := http.NewServeMux()
+	mux.Handle(c.Path, webhook.Handler(c.Secret, func(ev *webhook.Event) {
+		if len(c.Event) > 0 && !slices.Contains(c.Event, ev.Name) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			fmt.Fprintf(os.Stderr, "Dropped %s: shutting down\n", ev.Name)
+			return
+		}
+		select {
+		case events <- ev:
+		default:
+			fmt.Fprintf(os.Stderr, "Dropped %s: handler is falling behind\n", ev.Name)
+		}
+	}))
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		stop()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to finish in-flight requests: %v\n", err)
+		}
+	}()
+
+	signed := "unsigned deliveries accepted"
+	if c.Secret != "" {
+		signed = "signatures verified"
+	}
+	fmt.Fprintf(os.Stderr, "Listening for webhooks on http://%s%s (%s)\n", addr, c.Path, signed)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	// ListenAndServe returns as soon as Shutdown starts; wait for it so
+	// requests that finish in time still queue their events.
+	<-shutdown
+	mu.Lock()
+	closed = true
+	close(events)
+	mu.Unlock()
+	worker.Wait()
+	return nil
+}
+
+func (c *WebhookListenCmd) handle(ctx context.Context, app *App, ev *webhook.Event) {
+	if c.Exec == "" {
+		app.Printer.PrintNDJSON(ev)
+		return
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	if err := hooks.RunCommand(ctx, c.Exec, ev.Name, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+}
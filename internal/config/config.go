@@ -12,6 +12,22 @@ type Config struct {
 	BaseURL   string `yaml:"base_url"`
 	APIKey    string `yaml:"api_key"`
 	AccountID int    `yaml:"account_id"`
+
+	// Display preferences (optional)
+	TimeFormat string `yaml:"time_format,omitempty"` // relative, iso, local, unix
+	Timezone   string `yaml:"timezone,omitempty"`    // IANA name, e.g. Europe/Berlin
+	Theme      string `yaml:"theme,omitempty"`       // TUI theme: auto, a bundled theme name or a file
+	Mouse      bool   `yaml:"mouse,omitempty"`       // TUI mouse support and clickable links
+
+	Notifications *Notifications `yaml:"notifications,omitempty"`
+}
+
+// Notifications configures alerts for new assignments and incoming messages.
+type Notifications struct {
+	// Filters maps a conversation filter (me, unassigned, all) to a method:
+	// auto, bell, osc9, osc777, desktop or off. Unlisted filters are off.
+	Filters    map[string]string `yaml:"filters,omitempty"`
+	QuietHours string            `yaml:"quiet_hours,omitempty"` // e.g. 22:00-08:00, in timezone
 }
 
 func ConfigDir() (string, error) {
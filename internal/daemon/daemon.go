@@ -0,0 +1,232 @@
+// Package daemon polls conversations matching the watches in hooks.yaml and
+// raises hook events for new messages, assignments and SLA warnings.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/hooks"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
+	"github.com/chatwoot/chatwoot-cli/internal/watch"
+)
+
+// Daemon holds per-watch state between polls.
+type Daemon struct {
+	client *sdk.Client
+	cfg    *hooks.Config
+	userID int
+	now    func() time.Time
+
+	// Emit is called for every event, in the order events are detected.
+	Emit func(ctx context.Context, ev *hooks.Event)
+	// Log receives one line per poll error.
+	Log io.Writer
+
+	watches map[string]*watchState
+}
+
+type watchState struct {
+	tracker *watch.Tracker[sdk.Conversation]
+	convs   map[int]convState
+}
+
+// convState is what the daemon remembers about a conversation to tell
+// which events a change represents.
+type convState struct {
+	assigneeID    int
+	lastActivity  int64
+	lastMessageID int
+	slaWarned     int64 // waiting_since value already warned about
+}
+
+func New(client *sdk.Client, cfg *hooks.Config, userID int) *Daemon {
+	d := &Daemon{
+		client:  client,
+		cfg:     cfg,
+		userID:  userID,
+		now:     time.Now,
+		Log:     io.Discard,
+		watches: make(map[string]*watchState),
+	}
+	for _, w := range cfg.Watches {
+		d.watches[w.Name] = &watchState{
+			tracker: watch.Conversations(),
+			convs:   make(map[int]convState),
+		}
+	}
+	return d
+}
+
+// Run polls every cfg.Interval until ctx is cancelled. The first poll only
+// records a baseline (apart from SLA warnings, which depend on the current
+// state rather than a change).
+func (d *Daemon) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks every watch once.
+func (d *Daemon) Poll(ctx context.Context) {
+	for _, w := range d.cfg.Watches {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := d.pollWatch(ctx, w); err != nil {
+			fmt.Fprintf(d.Log, "%s watch %s: %v\n", d.now().Format("15:04:05"), w.Name, err)
+		}
+	}
+}
+
+func (d *Daemon) pollWatch(ctx context.Context, w hooks.Watch) error {
+	convs, err := d.fetch(w)
+	if err != nil {
+		return err
+	}
+
+	state := d.watches[w.Name]
+	primed := state.tracker.Primed()
+	diff := state.tracker.Update(convs)
+
+	changed := make(map[int]bool, len(diff.Added)+len(diff.Changed))
+	for _, c := range diff.Updated() {
+		changed[c.ID] = true
+	}
+
+	next := make(map[int]convState, len(convs))
+	for i := range convs {
+		conv := &convs[i]
+		prev, known := state.convs[conv.ID]
+		cur := d.snapshot(conv, prev)
+
+		if primed && changed[conv.ID] {
+			if cur.assigneeID == d.userID && d.userID != 0 && (!known || prev.assigneeID != d.userID) {
+				d.emit(ctx, hooks.ConversationAssigned, w.Name, conv, nil)
+			}
+			if known && conv.LastActivityAt > prev.lastActivity {
+				last, err := d.newMessages(ctx, w.Name, conv, prev)
+				if err != nil {
+					fmt.Fprintf(d.Log, "%s conversation %d: %v\n", d.now().Format("15:04:05"), conv.ID, err)
+				} else if last > cur.lastMessageID {
+					cur.lastMessageID = last
+				}
+			}
+		}
+
+		if d.slaDue(conv, cur) {
+			d.emit(ctx, hooks.SLAWarning, w.Name, conv, nil)
+			cur.slaWarned = conv.WaitingSince
+		}
+
+		next[conv.ID] = cur
+	}
+	state.convs = next
+	return nil
+}
+
+// fetch returns the first page of the watch's conversations.
+func (d *Daemon) fetch(w hooks.Watch) ([]sdk.Conversation, error) {
+	if w.Saved != nil {
+		convs, _, err := views.Fetch(d.client, *w.Saved, 1, d.userID)
+		return convs, err
+	}
+	resp, err := d.client.Conversations().List(w.ListOptions())
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data.Payload, nil
+}
+
+// snapshot records the current state of conv, carrying over what the list
+// payload doesn't tell us.
+func (d *Daemon) snapshot(conv *sdk.Conversation, prev convState) convState {
+	s := convState{
+		lastActivity:  conv.LastActivityAt,
+		lastMessageID: prev.lastMessageID,
+		slaWarned:     prev.slaWarned,
+	}
+	if conv.Meta.Assignee != nil {
+		s.assigneeID = conv.Meta.Assignee.ID
+	}
+	// The list payload includes the latest message.
+	for _, m := range conv.Messages {
+		if m.ID > s.lastMessageID {
+			s.lastMessageID = m.ID
+		}
+	}
+	// A changed waiting_since means the customer is waiting on a new reply.
+	if s.slaWarned != 0 && s.slaWarned != conv.WaitingSince {
+		s.slaWarned = 0
+	}
+	return s
+}
+
+// newMessages emits message.created for messages posted since prev and
+// returns the highest message ID seen.
+func (d *Daemon) newMessages(ctx context.Context, watchName string, conv *sdk.Conversation, prev convState) (int, error) {
+	msgs := d.client.Messages(conv.ID)
+
+	var resp *sdk.MessagesListResponse
+	var err error
+	if prev.lastMessageID > 0 {
+		resp, err = msgs.ListAfter(prev.lastMessageID)
+	} else {
+		resp, err = msgs.List(0)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch new messages: %w", err)
+	}
+
+	last := prev.lastMessageID
+	for i := range resp.Payload {
+		m := &resp.Payload[i]
+		if m.ID <= prev.lastMessageID || m.MessageType == 2 {
+			continue
+		}
+		// Without a known last message, fall back to the activity time.
+		if prev.lastMessageID == 0 && m.CreatedAt <= prev.lastActivity {
+			continue
+		}
+		d.emit(ctx, hooks.MessageCreated, watchName, conv, m)
+		if m.ID > last {
+			last = m.ID
+		}
+	}
+	return last, nil
+}
+
+// slaDue reports whether conv has waited long enough for an SLA warning
+// that hasn't been raised yet.
+func (d *Daemon) slaDue(conv *sdk.Conversation, s convState) bool {
+	sla := d.cfg.SLA
+	if sla.ReplyWithin == 0 || conv.WaitingSince == 0 || s.slaWarned == conv.WaitingSince {
+		return false
+	}
+	deadline := time.Unix(conv.WaitingSince, 0).Add(sla.ReplyWithin - sla.WarnBefore)
+	return !d.now().Before(deadline)
+}
+
+func (d *Daemon) emit(ctx context.Context, name, watchName string, conv *sdk.Conversation, msg *sdk.Message) {
+	if d.Emit == nil {
+		return
+	}
+	d.Emit(ctx, &hooks.Event{
+		Name:         name,
+		Watch:        watchName,
+		Conversation: conv,
+		Message:      msg,
+		OccurredAt:   d.now().UTC(),
+	})
+}
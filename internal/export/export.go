@@ -0,0 +1,256 @@
+// Package export writes a complete conversation transcript to a standalone
+// document (Markdown, HTML, plain text or JSON) for sharing outside Chatwoot.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/markdown"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/timefmt"
+)
+
+// Transcript is everything that goes into an export.
+type Transcript struct {
+	Conversation *sdk.Conversation `json:"conversation"`
+	Contact      *sdk.ContactFull  `json:"contact,omitempty"`
+	Messages     []sdk.Message     `json:"messages"`
+	URL          string            `json:"url,omitempty"`
+	ExportedAt   time.Time         `json:"exported_at"`
+}
+
+type Options struct {
+	Time *timefmt.Formatter
+	// EmbedImages inlines image attachments as data URIs (HTML only) so the
+	// file stays readable after attachment links expire.
+	EmbedImages bool
+}
+
+// FilterPrivate returns msgs without private notes.
+func FilterPrivate(msgs []sdk.Message) []sdk.Message {
+	out := make([]sdk.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if !msg.Private {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+// Write renders t in the given format.
+func Write(w io.Writer, format string, t *Transcript, opts Options) error {
+	if opts.Time == nil {
+		opts.Time = timefmt.Default()
+	}
+	switch format {
+	case "md":
+		return writeMarkdown(w, t, opts)
+	case "html":
+		return writeHTML(w, t, opts)
+	case "txt":
+		return writeText(w, t, opts)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	default:
+		return fmt.Errorf("unsupported export format %q (want md, html, txt or json)", format)
+	}
+}
+
+// field is one row of the metadata block shared by every text-based format.
+type field struct {
+	Key   string
+	Value string
+}
+
+func metadata(t *Transcript, tf *timefmt.Formatter) []field {
+	conv := t.Conversation
+	fields := []field{
+		{"Conversation", "#" + strconv.Itoa(conv.ID)},
+		{"Status", conv.Status},
+	}
+	if conv.Priority != nil {
+		fields = append(fields, field{"Priority", *conv.Priority})
+	}
+	if conv.Meta.Channel != "" {
+		fields = append(fields, field{"Channel", conv.Meta.Channel})
+	}
+	if conv.Meta.Assignee != nil {
+		fields = append(fields, field{"Assignee", conv.Meta.Assignee.Name})
+	}
+	if conv.Meta.Team != nil {
+		fields = append(fields, field{"Team", conv.Meta.Team.Name})
+	}
+	if len(conv.Labels) > 0 {
+		fields = append(fields, field{"Labels", strings.Join(conv.Labels, ", ")})
+	}
+	fields = append(fields,
+		field{"Created", tf.Format(conv.CreatedAt)},
+		field{"Last Activity", tf.Format(conv.LastActivityAt)},
+	)
+
+	if c := contactInfo(t); c != nil {
+		fields = append(fields, field{"Contact", c.Name})
+		if c.Email != "" {
+			fields = append(fields, field{"Email", c.Email})
+		}
+		if c.Phone != "" {
+			fields = append(fields, field{"Phone", c.Phone})
+		}
+		if c.Company != "" {
+			fields = append(fields, field{"Company", c.Company})
+		}
+	}
+
+	if t.URL != "" {
+		fields = append(fields, field{"Link", t.URL})
+	}
+	fields = append(fields, field{"Exported", t.ExportedAt.In(tf.Location).Format(time.RFC3339)})
+	return fields
+}
+
+type contact struct {
+	Name, Email, Phone, Company string
+}
+
+// contactInfo prefers the fully fetched contact, falling back to the
+// conversation's sender summary.
+func contactInfo(t *Transcript) *contact {
+	if t.Contact != nil {
+		return &contact{t.Contact.Name, t.Contact.Email, t.Contact.PhoneNumber, t.Contact.CompanyName}
+	}
+	if s := t.Conversation.Meta.Sender; s != nil {
+		return &contact{Name: s.Name, Email: s.Email, Phone: s.Phone}
+	}
+	return nil
+}
+
+func senderName(msg sdk.Message) string {
+	if msg.Sender != nil && msg.Sender.Name != "" {
+		return msg.Sender.Name
+	}
+	return "Unknown"
+}
+
+// messageKind labels a message for readers who can't see styling.
+func messageKind(msg sdk.Message) string {
+	switch {
+	case msg.Private:
+		return "private note"
+	case msg.MessageType == 0:
+		return "incoming"
+	case msg.MessageType == 1:
+		return "outgoing"
+	case msg.MessageType == 2:
+		return "activity"
+	default:
+		return "message"
+	}
+}
+
+func attachmentLabel(att sdk.Attachment) string {
+	label := att.FileType
+	if label == "" {
+		label = "file"
+	}
+	if att.FileSize > 0 {
+		label += fmt.Sprintf(", %d bytes", att.FileSize)
+	}
+	return label
+}
+
+// markdownURL escapes the characters that would end a link target early.
+var markdownURL = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20")
+
+func writeMarkdown(w io.Writer, t *Transcript, opts Options) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Conversation #%d\n\n", t.Conversation.ID)
+	for _, f := range metadata(t, opts.Time) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", f.Key, f.Value)
+	}
+	b.WriteString("\n## Messages\n")
+
+	for _, msg := range t.Messages {
+		ts := opts.Time.Format(msg.CreatedAt)
+		if msg.MessageType == 2 {
+			fmt.Fprintf(&b, "\n*%s — %s*\n", strings.TrimSpace(msg.Content), ts)
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n### %s · %s\n\n", senderName(msg), ts)
+		fmt.Fprintf(&b, "<sub>#%d · %s</sub>\n\n", msg.ID, messageKind(msg))
+
+		content := markdown.PlainMentions(strings.TrimSpace(msg.Content))
+		if msg.Private {
+			// Blockquote private notes so they stand apart from the conversation
+			content = "**Private note**\n\n" + content
+			content = "> " + strings.ReplaceAll(content, "\n", "\n> ")
+		}
+		if content != "" {
+			b.WriteString(content + "\n")
+		}
+
+		for _, att := range msg.Attachments {
+			// Like the HTML export, only link http(s) URLs
+			if isHTTP(att.DataURL) {
+				fmt.Fprintf(&b, "\n- 📎 [%s](%s)\n", attachmentLabel(att), markdownURL.Replace(att.DataURL))
+			} else {
+				fmt.Fprintf(&b, "\n- 📎 %s\n", attachmentLabel(att))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeText(w io.Writer, t *Transcript, opts Options) error {
+	var b strings.Builder
+	title := fmt.Sprintf("Conversation #%d", t.Conversation.ID)
+	b.WriteString(title + "\n" + strings.Repeat("=", len(title)) + "\n\n")
+
+	fields := metadata(t, opts.Time)
+	maxKey := 0
+	for _, f := range fields {
+		if len(f.Key) > maxKey {
+			maxKey = len(f.Key)
+		}
+	}
+	for _, f := range fields {
+		fmt.Fprintf(&b, "%-*s  %s\n", maxKey+1, f.Key+":", f.Value)
+	}
+
+	b.WriteString("\nMessages\n--------\n")
+
+	for _, msg := range t.Messages {
+		ts := opts.Time.Format(msg.CreatedAt)
+		if msg.MessageType == 2 {
+			fmt.Fprintf(&b, "\n  -- %s (%s) --\n", strings.TrimSpace(msg.Content), ts)
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n[%s] %s (%s, #%d)\n", ts, senderName(msg), messageKind(msg), msg.ID)
+		prefix := "    "
+		if msg.Private {
+			prefix = "  | "
+		}
+		content := markdown.PlainMentions(strings.TrimSpace(msg.Content))
+		if content != "" {
+			for _, line := range strings.Split(content, "\n") {
+				b.WriteString(prefix + line + "\n")
+			}
+		}
+		for _, att := range msg.Attachments {
+			fmt.Fprintf(&b, "%sAttachment (%s): %s\n", prefix, attachmentLabel(att), att.DataURL)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
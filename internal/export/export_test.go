@@ -0,0 +1,42 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+func TestMarkdownAttachments(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"https", "https://example.com/a.png", "- 📎 [image](https://example.com/a.png)\n"},
+		{"parens and spaces", "https://example.com/a (1).png", "- 📎 [image](https://example.com/a%20%281%29.png)\n"},
+		{"javascript url", "javascript:alert(1)", "- 📎 image\n"},
+		{"data url", "data:text/html;base64,PHNjcmlwdD4=", "- 📎 image\n"},
+		{"no url", "", "- 📎 image\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcript := &Transcript{
+				Conversation: &sdk.Conversation{ID: 1},
+				Messages: []sdk.Message{{
+					ID:          1,
+					Content:     "see attached",
+					Attachments: []sdk.Attachment{{ID: 1, FileType: "image", DataURL: tt.url}},
+				}},
+			}
+			var buf bytes.Buffer
+			if err := Write(&buf, "md", transcript, Options{}); err != nil {
+				t.Fatalf("Write() = %v", err)
+			}
+			if out := buf.String(); !strings.HasSuffix(out, tt.want) {
+				t.Errorf("output ends %q, want %q", out[max(0, len(out)-60):], tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/markdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// maxEmbedSize caps how large an attachment may be to be inlined.
+const maxEmbedSize = 10 << 20
+
+var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+type htmlMessage struct {
+	ID          int
+	Sender      string
+	Time        string
+	Kind        string
+	Class       string
+	Activity    string // set for activity messages, rendered as plain text
+	Content     template.HTML
+	Attachments []htmlAttachment
+}
+
+type htmlAttachment struct {
+	Label string
+	URL   string
+	Src   template.URL // set when the image is embedded or linkable inline
+}
+
+// writeHTML produces a single self-contained page. Styles are inline and
+// include print rules, so the page can be saved as PDF from any browser.
+func writeHTML(w io.Writer, t *Transcript, opts Options) error {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	msgs := make([]htmlMessage, 0, len(t.Messages))
+	for _, msg := range t.Messages {
+		hm := htmlMessage{
+			ID:     msg.ID,
+			Sender: senderName(msg),
+			Time:   opts.Time.Format(msg.CreatedAt),
+			Kind:   messageKind(msg),
+			Class:  strings.ReplaceAll(messageKind(msg), " ", "-"),
+		}
+		if msg.MessageType == 2 {
+			hm.Activity = strings.TrimSpace(msg.Content)
+			if hm.Activity == "" {
+				hm.Activity = "(activity)"
+			}
+		} else {
+			hm.Content = renderHTML(msg.Content)
+		}
+		for _, att := range msg.Attachments {
+			ha := htmlAttachment{Label: attachmentLabel(att), URL: att.DataURL}
+			if att.FileType == "image" {
+				// Only trusted as a URL when it's plain http(s); anything else
+				// goes through html/template's sanitizing as a link
+				if isHTTP(att.DataURL) {
+					ha.Src = template.URL(att.DataURL)
+				}
+				if opts.EmbedImages {
+					if uri, ok := dataURI(httpClient, att.DataURL); ok {
+						ha.Src = uri
+					}
+				}
+			}
+			hm.Attachments = append(hm.Attachments, ha)
+		}
+		msgs = append(msgs, hm)
+	}
+
+	return htmlTemplate.Execute(w, map[string]interface{}{
+		"ID":       t.Conversation.ID,
+		"Fields":   metadata(t, opts.Time),
+		"Messages": msgs,
+	})
+}
+
+// renderHTML converts message markdown to HTML. goldmark escapes raw HTML
+// by default, so customer-supplied markup cannot inject into the page.
+func renderHTML(content string) template.HTML {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(markdown.PlainMentions(strings.TrimSpace(content))), &buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(content))
+	}
+	return template.HTML(buf.String())
+}
+
+func isHTTP(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// dataURI downloads an image and returns it as a base64 data URI.
+func dataURI(client *http.Client, rawURL string) (template.URL, bool) {
+	if !isHTTP(rawURL) {
+		return "", false
+	}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxEmbedSize+1))
+	if err != nil || len(data) > maxEmbedSize {
+		return "", false
+	}
+
+	mime := resp.Header.Get("Content-Type")
+	if mime == "" {
+		mime = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(mime, "image/") {
+		return "", false
+	}
+	return template.URL("data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data)), true
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Conversation #{{.ID}}</title>
+<style>
+  body { font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; max-width: 820px; margin: 2rem auto; padding: 0 1rem; }
+  h1 { font-size: 1.5rem; margin-bottom: .5rem; }
+  table.meta { border-collapse: collapse; margin-bottom: 2rem; }
+  table.meta th { text-align: left; color: #656d76; font-weight: 500; padding: 2px 16px 2px 0; vertical-align: top; }
+  .message { border: 1px solid #d0d7de; border-radius: 8px; padding: .5rem .75rem; margin: .75rem 0; page-break-inside: avoid; }
+  .message.outgoing { background: #eef5ff; border-color: #a8c7fa; margin-left: 15%; }
+  .message.incoming { margin-right: 15%; }
+  .message.private-note { background: #fff8e5; border-color: #d4a72c; }
+  .message header { font-size: 12px; color: #656d76; margin-bottom: .25rem; }
+  .message header strong { color: #1f2328; }
+  .tag { display: inline-block; font-size: 11px; padding: 0 6px; border-radius: 8px; background: #d4a72c; color: #fff; margin-left: 4px; }
+  .activity { text-align: center; color: #656d76; font-style: italic; font-size: 12px; margin: .5rem 0; }
+  .content p { margin: .25rem 0; }
+  .attachments { margin-top: .5rem; font-size: 12px; }
+  .attachments img { display: block; max-width: 100%; max-height: 320px; margin: .25rem 0; border-radius: 4px; }
+  @media print {
+    body { margin: 0; max-width: none; }
+    .message { border-color: #999; }
+    a { color: inherit; }
+  }
+</style>
+</head>
+<body>
+<h1>Conversation #{{.ID}}</h1>
+<table class="meta">
+{{- range .Fields}}
+  <tr><th>{{.Key}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{range .Messages -}}
+{{if .Activity -}}
+<div class="activity">{{.Activity}} · {{.Time}}</div>
+{{else -}}
+<section class="message {{.Class}}" id="message-{{.ID}}">
+  <header><strong>{{.Sender}}</strong> · {{.Time}} · #{{.ID}}{{if eq .Kind "private note"}}<span class="tag">Private note</span>{{end}}</header>
+  <div class="content">{{.Content}}</div>
+  {{- if .Attachments}}
+  <div class="attachments">
+  {{- range .Attachments}}
+    {{if .Src}}<a href="{{.URL}}"><img src="{{.Src}}" alt="{{.Label}}"></a>{{else}}<a href="{{.URL}}">📎 {{.Label}}</a>{{end}}
+  {{- end}}
+  </div>
+  {{- end}}
+</section>
+{{end -}}
+{{end -}}
+</body>
+</html>
+`))
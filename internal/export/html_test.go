@@ -0,0 +1,62 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+func TestHTMLAttachments(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cat.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<script>alert(1)</script>"))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		embed   bool
+		want    string
+		notWant string
+	}{
+		{"https image", "https://example.com/a.png", false, `<img src="https://example.com/a.png"`, ""},
+		{"javascript url", "javascript:alert(1)", false, `href="#ZgotmplZ"`, "<img"},
+		{"data url from server", "data:text/html;base64,PHNjcmlwdD4=", false, `href="#ZgotmplZ"`, "<img"},
+		{"embedded image", srv.URL + "/cat.png", true, `<img src="data:image/png;base64,cG5n"`, ""},
+		{"embedded non-image", srv.URL + "/page", true, `<img src="` + srv.URL + `/page"`, "data:text/html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcript := &Transcript{
+				Conversation: &sdk.Conversation{ID: 1},
+				Messages: []sdk.Message{{
+					ID:          1,
+					Content:     "see attached",
+					Attachments: []sdk.Attachment{{ID: 1, FileType: "image", DataURL: tt.url}},
+				}},
+			}
+			var buf bytes.Buffer
+			if err := Write(&buf, "html", transcript, Options{EmbedImages: tt.embed}); err != nil {
+				t.Fatalf("Write() = %v", err)
+			}
+			out := buf.String()
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output lacks %q", tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(out, tt.notWant) {
+				t.Errorf("output contains %q", tt.notWant)
+			}
+		})
+	}
+}
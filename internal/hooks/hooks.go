@@ -0,0 +1,282 @@
+// Package hooks runs user-defined automation for Chatwoot events: shell
+// commands that receive the event JSON on stdin, or HTTP endpoints that
+// receive it as a POST body.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
+	"gopkg.in/yaml.v3"
+)
+
+// Events raised by the daemon.
+const (
+	MessageCreated       = "message.created"
+	ConversationAssigned = "conversation.assigned"
+	SLAWarning           = "sla.warning"
+)
+
+var Events = []string{MessageCreated, ConversationAssigned, SLAWarning}
+
+// Event is the JSON document hooks receive.
+type Event struct {
+	Name         string            `json:"event"`
+	Watch        string            `json:"watch,omitempty"`
+	Conversation *sdk.Conversation `json:"conversation,omitempty"`
+	Message      *sdk.Message      `json:"message,omitempty"`
+	OccurredAt   time.Time         `json:"occurred_at"`
+}
+
+// Config is the contents of hooks.yaml.
+type Config struct {
+	Interval time.Duration `yaml:"interval"`
+	SLA      SLA           `yaml:"sla"`
+	Watches  []Watch       `yaml:"watches"`
+	Hooks    []Hook        `yaml:"hooks"`
+}
+
+// Watch selects the conversations to monitor, using the same filters as
+// `conversation list`, or a saved view by name.
+type Watch struct {
+	Name     string   `yaml:"name"`
+	View     string   `yaml:"view"`     // saved view; excludes the filters below
+	Status   string   `yaml:"status"`   // default: open
+	Assignee string   `yaml:"assignee"` // me (default), unassigned, all
+	Inbox    int      `yaml:"inbox"`
+	Team     int      `yaml:"team"`
+	Labels   []string `yaml:"labels"`
+
+	// Saved is the view named by View, resolved by Load.
+	Saved *views.View `yaml:"-"`
+}
+
+func (w Watch) ListOptions() sdk.ListOptions {
+	return sdk.ListOptions{
+		Status:       w.Status,
+		InboxID:      w.Inbox,
+		AssigneeType: w.Assignee,
+		TeamID:       w.Team,
+		Labels:       w.Labels,
+		SortBy:       "last_activity_at_desc",
+		Page:         1,
+	}
+}
+
+// SLA raises sla.warning when a customer has waited longer than
+// ReplyWithin minus WarnBefore for a reply.
+type SLA struct {
+	ReplyWithin time.Duration `yaml:"reply_within"`
+	WarnBefore  time.Duration `yaml:"warn_before"`
+}
+
+// Hook runs Command or posts to URL for matching events.
+type Hook struct {
+	Name    string            `yaml:"name"`
+	On      []string          `yaml:"on"`
+	Watch   string            `yaml:"watch"` // optional; limit to one watch
+	Command string            `yaml:"command"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// Matches reports whether the hook should fire for ev.
+func (h Hook) Matches(ev *Event) bool {
+	if h.Watch != "" && h.Watch != ev.Watch {
+		return false
+	}
+	return slices.Contains(h.On, ev.Name)
+}
+
+// Run delivers ev to the hook's command or URL.
+func (h Hook) Run(ctx context.Context, ev *Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if h.Command != "" {
+		return RunCommand(ctx, h.Command, ev.Name, payload)
+	}
+	return Post(ctx, h.URL, h.Headers, payload)
+}
+
+func ConfigPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hooks.yaml"), nil
+}
+
+// Load reads and validates the hooks file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no hooks configured; create %s", path)
+		}
+		return nil, fmt.Errorf("failed to read hooks: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse hooks: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid hooks config: %w", err)
+	}
+	if err := cfg.resolveViews(); err != nil {
+		return nil, fmt.Errorf("invalid hooks config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// resolveViews looks up the saved views that watches refer to.
+func (c *Config) resolveViews() error {
+	var saved []views.View
+	loaded := false
+	for i := range c.Watches {
+		w := &c.Watches[i]
+		if w.View == "" {
+			continue
+		}
+		if !loaded {
+			var err error
+			if saved, err = views.Load(); err != nil {
+				return err
+			}
+			loaded = true
+		}
+		v, err := views.Find(saved, w.View)
+		if err != nil {
+			return fmt.Errorf("watch %q: %w", w.Name, err)
+		}
+		w.Saved = v
+	}
+	return nil
+}
+
+// validate checks references and fills in defaults.
+func (c *Config) validate() error {
+	if c.Interval == 0 {
+		c.Interval = 30 * time.Second
+	}
+	if c.Interval < 5*time.Second {
+		return fmt.Errorf("interval must be at least 5s")
+	}
+
+	if len(c.Watches) == 0 {
+		c.Watches = []Watch{{Name: "mine"}}
+	}
+	names := map[string]bool{}
+	for i := range c.Watches {
+		w := &c.Watches[i]
+		if w.Name == "" {
+			return fmt.Errorf("watch %d has no name", i+1)
+		}
+		if names[w.Name] {
+			return fmt.Errorf("duplicate watch %q", w.Name)
+		}
+		names[w.Name] = true
+		if w.View != "" {
+			if w.Status != "" || w.Assignee != "" || w.Inbox != 0 || w.Team != 0 || len(w.Labels) > 0 {
+				return fmt.Errorf("watch %q: view can't be combined with other filters", w.Name)
+			}
+			continue
+		}
+		if w.Status == "" {
+			w.Status = "open"
+		}
+		if w.Assignee == "" {
+			w.Assignee = "me"
+		}
+	}
+
+	if len(c.Hooks) == 0 {
+		return fmt.Errorf("no hooks defined")
+	}
+	for i, h := range c.Hooks {
+		label := h.Name
+		if label == "" {
+			label = fmt.Sprintf("hook %d", i+1)
+		}
+		if (h.Command == "") == (h.URL == "") {
+			return fmt.Errorf("%s: set exactly one of command or url", label)
+		}
+		if len(h.On) == 0 {
+			return fmt.Errorf("%s: no events in 'on'", label)
+		}
+		for _, ev := range h.On {
+			if !slices.Contains(Events, ev) {
+				return fmt.Errorf("%s: unknown event %q", label, ev)
+			}
+		}
+		if h.Watch != "" && !names[h.Watch] {
+			return fmt.Errorf("%s: unknown watch %q", label, h.Watch)
+		}
+		if slices.Contains(h.On, SLAWarning) && c.SLA.ReplyWithin == 0 {
+			return fmt.Errorf("%s: sla.warning requires sla.reply_within", label)
+		}
+	}
+	return nil
+}
+
+// RunCommand runs command through the system shell with payload on stdin.
+// The event name is exported as CHATWOOT_EVENT. Output goes to the
+// current process's stdout and stderr.
+func RunCommand(ctx context.Context, command, event string, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "CHATWOOT_EVENT="+event)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook %q failed: %w", command, err)
+	}
+	return nil
+}
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Post sends payload as a JSON POST body to url.
+func Post(ctx context.Context, url string, headers map[string]string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("hook %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("hook %s returned %d: %s", url, resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateWatches(t *testing.T) {
+	hook := []Hook{{On: []string{MessageCreated}, Command: "cat"}}
+
+	tests := []struct {
+		name    string
+		watches []Watch
+		wantErr string
+		want    Watch // first watch after defaults
+	}{
+		{"default watch", nil, "", Watch{Name: "mine", Status: "open", Assignee: "me"}},
+		{"filters get defaults", []Watch{{Name: "w", Inbox: 2}}, "", Watch{Name: "w", Status: "open", Assignee: "me", Inbox: 2}},
+		{"view keeps no defaults", []Watch{{Name: "w", View: "urgent"}}, "", Watch{Name: "w", View: "urgent"}},
+		{"view with filters", []Watch{{Name: "w", View: "urgent", Labels: []string{"vip"}}}, "can't be combined", Watch{}},
+		{"duplicate", []Watch{{Name: "w"}, {Name: "w"}}, "duplicate watch", Watch{}},
+		{"no name", []Watch{{View: "urgent"}}, "has no name", Watch{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Watches: tt.watches, Hooks: hook}
+			err := cfg.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := cfg.Watches[0]
+			if got.Name != tt.want.Name || got.View != tt.want.View || got.Status != tt.want.Status ||
+				got.Assignee != tt.want.Assignee || got.Inbox != tt.want.Inbox {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
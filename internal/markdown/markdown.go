@@ -0,0 +1,55 @@
+// Package markdown renders Chatwoot message content with glamour. It is
+// shared by the TUI message pane and the CLI transcript so both show
+// mentions and formatting the same way.
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+)
+
+// mentionRe matches [@Name](mention://user/ID/Name) or [@Name](mention://team/ID/Name)
+// and captures the display text (the part inside [ ]).
+var mentionRe = regexp.MustCompile(`\[@([^\]]+)\]\(mention://(?:user|team)/\d+/[^)]+\)`)
+
+// ReplaceMentions rewrites mention links as bold @Name so they read naturally
+// once rendered.
+func ReplaceMentions(content string) string {
+	return mentionRe.ReplaceAllString(content, "**@$1**")
+}
+
+// PlainMentions rewrites mention links as bare @Name for non-markdown output.
+func PlainMentions(content string) string {
+	return mentionRe.ReplaceAllString(content, "@$1")
+}
+
+// BaseStyle returns glamour's stock style for the given background.
+// Detect the background before bubbletea takes over the terminal;
+// glamour's WithAutoStyle deadlocks inside a running program.
+func BaseStyle(dark bool) ansi.StyleConfig {
+	if dark {
+		return styles.DarkStyleConfig
+	}
+	return styles.LightStyleConfig
+}
+
+// ChatStyle strips document-level whitespace from cfg. Message content lives
+// inside boxes or indented blocks, so the default margins only waste space.
+func ChatStyle(cfg ansi.StyleConfig) ansi.StyleConfig {
+	zero := uint(0)
+	cfg.Document.Margin = &zero
+	cfg.Document.BlockPrefix = ""
+	cfg.Document.BlockSuffix = ""
+	return cfg
+}
+
+// NewRenderer creates a renderer using the given style that wraps at width.
+func NewRenderer(cfg ansi.StyleConfig, width int) (*glamour.TermRenderer, error) {
+	return glamour.NewTermRenderer(
+		glamour.WithStyles(cfg),
+		glamour.WithWordWrap(width),
+	)
+}
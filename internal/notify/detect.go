@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// Detector compares successive polls of one conversation filter and
+// reports new assignments to the current agent and new incoming messages.
+// The first poll only records a baseline.
+type Detector struct {
+	userID int
+	seen   map[int]seenConv
+}
+
+type seenConv struct {
+	assigneeID    int
+	lastActivity  int64
+	unread        int
+	lastMessageID int
+}
+
+func NewDetector(userID int) *Detector {
+	return &Detector{userID: userID}
+}
+
+// SetUser sets the agent whose assignments are reported.
+func (d *Detector) SetUser(id int) {
+	d.userID = id
+}
+
+// Reset forgets the baseline, e.g. when switching filters.
+func (d *Detector) Reset() {
+	d.seen = nil
+}
+
+// Learn records convs as seen without notifying, e.g. when a list loads a
+// further page. Until the first Update there is nothing to add them to.
+func (d *Detector) Learn(convs []sdk.Conversation) {
+	if d.seen == nil {
+		return
+	}
+	for _, conv := range convs {
+		d.seen[conv.ID] = observe(conv, d.seen[conv.ID])
+	}
+}
+
+// observe returns the state to remember for conv, given what was
+// remembered before (zero if it wasn't seen).
+func observe(conv sdk.Conversation, prev seenConv) seenConv {
+	cur := seenConv{
+		assigneeID:    assigneeID(conv),
+		lastActivity:  conv.LastActivityAt,
+		unread:        conv.UnreadCount,
+		lastMessageID: prev.lastMessageID,
+	}
+	if last := lastMessage(conv); last != nil && last.ID > cur.lastMessageID {
+		cur.lastMessageID = last.ID
+	}
+	return cur
+}
+
+// Update records convs as the latest poll and returns the notifications
+// it implies.
+func (d *Detector) Update(convs []sdk.Conversation) []Notification {
+	primed := d.seen != nil
+	next := make(map[int]seenConv, len(convs))
+	var notes []Notification
+
+	for _, conv := range convs {
+		prev, known := d.seen[conv.ID]
+		cur := observe(conv, prev)
+		last := lastMessage(conv)
+		next[conv.ID] = cur
+
+		if !primed {
+			continue
+		}
+		switch {
+		case d.userID != 0 && cur.assigneeID == d.userID && (!known || prev.assigneeID != d.userID):
+			notes = append(notes, Notification{
+				ConversationID: conv.ID,
+				Title:          fmt.Sprintf("Assigned to you: #%d", conv.ID),
+				Body:           summary(conv, last),
+			})
+		case !known:
+			if last == nil || last.MessageType == 0 {
+				notes = append(notes, Notification{
+					ConversationID: conv.ID,
+					Title:          fmt.Sprintf("New conversation #%d from %s", conv.ID, senderName(conv)),
+					Body:           summary(conv, last),
+				})
+			}
+		case conv.LastActivityAt > prev.lastActivity && incoming(prev, cur, last):
+			notes = append(notes, Notification{
+				ConversationID: conv.ID,
+				Title:          fmt.Sprintf("%s (#%d)", senderName(conv), conv.ID),
+				Body:           summary(conv, last),
+			})
+		}
+	}
+
+	d.seen = next
+	return notes
+}
+
+// Message records a pushed message for conv and returns a notification if
+// it is a new incoming message. Later polls won't report it again.
+func (d *Detector) Message(conv sdk.Conversation, msg sdk.Message) (Notification, bool) {
+	if d.seen == nil {
+		d.seen = map[int]seenConv{}
+	}
+	prev := d.seen[conv.ID]
+	if msg.ID <= prev.lastMessageID {
+		return Notification{}, false
+	}
+	prev.lastMessageID = msg.ID
+	if msg.CreatedAt > prev.lastActivity {
+		prev.lastActivity = msg.CreatedAt
+	}
+	d.seen[conv.ID] = prev
+
+	if msg.MessageType != 0 || msg.Private {
+		return Notification{}, false
+	}
+	return Notification{
+		ConversationID: conv.ID,
+		Title:          fmt.Sprintf("%s (#%d)", senderName(conv), conv.ID),
+		Body:           msg.Content,
+	}, true
+}
+
+// incoming reports whether the activity between two polls was a new
+// customer message. The list payload carries the latest message; when it
+// is missing, a rising unread count is the best signal.
+func incoming(prev, cur seenConv, last *sdk.Message) bool {
+	if last != nil {
+		return last.ID > prev.lastMessageID && last.MessageType == 0 && !last.Private
+	}
+	return cur.unread > prev.unread
+}
+
+func lastMessage(conv sdk.Conversation) *sdk.Message {
+	if len(conv.Messages) == 0 {
+		return nil
+	}
+	return &conv.Messages[len(conv.Messages)-1]
+}
+
+func assigneeID(conv sdk.Conversation) int {
+	if conv.Meta.Assignee != nil {
+		return conv.Meta.Assignee.ID
+	}
+	return 0
+}
+
+func senderName(conv sdk.Conversation) string {
+	if conv.Meta.Sender != nil && conv.Meta.Sender.Name != "" {
+		return conv.Meta.Sender.Name
+	}
+	return "Unknown"
+}
+
+func summary(conv sdk.Conversation, last *sdk.Message) string {
+	if last != nil && last.Content != "" {
+		return last.Content
+	}
+	return senderName(conv)
+}
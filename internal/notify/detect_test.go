@@ -0,0 +1,112 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+const me = 7
+
+func conv(id int, assignee int, activity int64, msgs ...sdk.Message) sdk.Conversation {
+	c := sdk.Conversation{ID: id, LastActivityAt: activity, Messages: msgs}
+	c.Meta.Sender = &sdk.Contact{Name: "Ann"}
+	if assignee != 0 {
+		c.Meta.Assignee = &sdk.Agent{ID: assignee}
+	}
+	return c
+}
+
+func msg(id, messageType int, content string) sdk.Message {
+	return sdk.Message{ID: id, MessageType: messageType, Content: content}
+}
+
+func TestDetectorUpdate(t *testing.T) {
+	baseline := []sdk.Conversation{
+		conv(1, 0, 100, msg(10, 0, "hello")),
+		conv(2, me, 100, msg(20, 0, "hi")),
+	}
+	tests := []struct {
+		name string
+		next []sdk.Conversation
+		want []string // notification titles
+	}{
+		{"nothing changed", baseline, nil},
+		{"assigned to me", []sdk.Conversation{conv(1, me, 100, msg(10, 0, "hello")), baseline[1]},
+			[]string{"Assigned to you: #1"}},
+		{"assigned to someone else", []sdk.Conversation{conv(1, 3, 100, msg(10, 0, "hello")), baseline[1]}, nil},
+		{"new incoming message", []sdk.Conversation{baseline[0], conv(2, me, 200, msg(21, 0, "more"))},
+			[]string{"Ann (#2)"}},
+		{"own reply", []sdk.Conversation{baseline[0], conv(2, me, 200, msg(21, 1, "reply"))}, nil},
+		{"private note", []sdk.Conversation{baseline[0], conv(2, me, 200, sdk.Message{ID: 21, Private: true})}, nil},
+		{"new conversation", append(baseline[:2:2], conv(3, 0, 300, msg(30, 0, "new"))),
+			[]string{"New conversation #3 from Ann"}},
+		{"new conversation assigned to me", append(baseline[:2:2], conv(3, me, 300, msg(30, 0, "new"))),
+			[]string{"Assigned to you: #3"}},
+		{"removed", baseline[:1], nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDetector(me)
+			if notes := d.Update(baseline); len(notes) != 0 {
+				t.Fatalf("baseline notified %v", notes)
+			}
+			notes := d.Update(tt.next)
+			var got []string
+			for _, n := range notes {
+				got = append(got, n.Title)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("notified %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("notified %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectorUnreadWithoutMessages(t *testing.T) {
+	d := NewDetector(me)
+	c := conv(1, 0, 100)
+	d.Update([]sdk.Conversation{c})
+
+	c.LastActivityAt, c.UnreadCount = 200, 1
+	if notes := d.Update([]sdk.Conversation{c}); len(notes) != 1 {
+		t.Errorf("notified %v, want one for the unread count", notes)
+	}
+}
+
+func TestDetectorMessage(t *testing.T) {
+	d := NewDetector(me)
+	c := conv(1, 0, 100, msg(10, 0, "hello"))
+	d.Update([]sdk.Conversation{c})
+
+	if _, ok := d.Message(c, msg(10, 0, "hello")); ok {
+		t.Error("notified for an already seen message")
+	}
+	note, ok := d.Message(c, sdk.Message{ID: 11, Content: "pushed", CreatedAt: 150})
+	if !ok || note.Body != "pushed" {
+		t.Errorf("Message() = %+v, %v; want a notification", note, ok)
+	}
+	if _, ok := d.Message(c, msg(12, 1, "reply")); ok {
+		t.Error("notified for an outgoing message")
+	}
+
+	// The next poll carries the pushed message and must not repeat it
+	c = conv(1, 0, 150, msg(11, 0, "pushed"))
+	if notes := d.Update([]sdk.Conversation{c}); len(notes) != 0 {
+		t.Errorf("poll repeated pushed message: %v", notes)
+	}
+}
+
+func TestDetectorReset(t *testing.T) {
+	d := NewDetector(me)
+	d.Update([]sdk.Conversation{conv(1, 0, 100)})
+	d.Reset()
+	if notes := d.Update([]sdk.Conversation{conv(2, me, 100)}); len(notes) != 0 {
+		t.Errorf("first poll after Reset notified %v", notes)
+	}
+}
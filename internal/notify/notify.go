@@ -0,0 +1,201 @@
+// Package notify alerts the agent about new assignments and incoming
+// messages with a terminal bell, an OSC 9/777 escape sequence (which
+// terminals turn into desktop notifications, even over SSH) or notify-send.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+)
+
+type Method string
+
+const (
+	Auto    Method = "auto"
+	Bell    Method = "bell"
+	OSC9    Method = "osc9"
+	OSC777  Method = "osc777"
+	Desktop Method = "desktop"
+	Off     Method = "off"
+)
+
+var Methods = []Method{Auto, Bell, OSC9, OSC777, Desktop, Off}
+
+func ParseMethod(s string) (Method, error) {
+	if s == "" {
+		return Auto, nil
+	}
+	for _, m := range Methods {
+		if string(m) == strings.ToLower(s) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid notification method %q (want auto, bell, osc9, osc777, desktop or off)", s)
+}
+
+// Notification is one alert about a conversation.
+type Notification struct {
+	ConversationID int
+	Title          string
+	Body           string
+}
+
+// Notifier sends notifications for the filters enabled in config.
+type Notifier struct {
+	methods map[string]Method // keyed by assignee filter: me, unassigned, all
+	quiet   *quietHours
+	out     io.Writer
+	now     func() time.Time
+}
+
+// New builds a notifier from the notifications section of config.yaml.
+// A nil cfg gives a notifier with every filter disabled. Escape sequences
+// are written to out, which should be the terminal. Quiet hours are read
+// in loc, the configured timezone; nil means local time.
+func New(cfg *config.Notifications, out io.Writer, loc *time.Location) (*Notifier, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	n := &Notifier{methods: map[string]Method{}, out: out, now: func() time.Time { return time.Now().In(loc) }}
+	if cfg == nil {
+		return n, nil
+	}
+	for filter, s := range cfg.Filters {
+		m, err := ParseMethod(s)
+		if err != nil {
+			return nil, fmt.Errorf("notifications.filters.%s: %w", filter, err)
+		}
+		if m != Off {
+			n.methods[filter] = m
+		}
+	}
+	if cfg.QuietHours != "" {
+		q, err := parseQuietHours(cfg.QuietHours)
+		if err != nil {
+			return nil, err
+		}
+		n.quiet = q
+	}
+	return n, nil
+}
+
+// Enable turns on notifications for filter with the auto method, unless
+// config already chose one.
+func (n *Notifier) Enable(filter string) {
+	if _, ok := n.methods[filter]; !ok {
+		n.methods[filter] = Auto
+	}
+}
+
+// Enabled reports whether polls of filter should produce notifications.
+func (n *Notifier) Enabled(filter string) bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n.methods[filter]
+	return ok
+}
+
+// Notify sends note using the method configured for filter. It does
+// nothing for disabled filters or during quiet hours.
+func (n *Notifier) Notify(filter string, note Notification) error {
+	if !n.Enabled(filter) || n.quiet.contains(n.now()) {
+		return nil
+	}
+
+	method := n.methods[filter]
+	if method == Auto {
+		method = detect()
+	}
+	switch method {
+	case Desktop:
+		return exec.Command("notify-send", "--app-name=Chatwoot", note.Title, note.Body).Run()
+	case OSC9:
+		return n.write(fmt.Sprintf("\x1b]9;%s: %s\x07", clean(note.Title), clean(note.Body)))
+	case OSC777:
+		return n.write(fmt.Sprintf("\x1b]777;notify;%s;%s\x07", clean(note.Title), clean(note.Body)))
+	default:
+		return n.write("\a")
+	}
+}
+
+// write sends an escape sequence to the terminal, wrapped for tmux
+// passthrough when running inside tmux.
+func (n *Notifier) write(seq string) error {
+	if os.Getenv("TMUX") != "" && seq != "\a" {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	_, err := io.WriteString(n.out, seq)
+	return err
+}
+
+// detect picks notify-send on a local graphical session and OSC 9
+// everywhere else (SSH, headless), which most modern terminals support.
+func detect() Method {
+	local := os.Getenv("SSH_CONNECTION") == "" &&
+		(os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "")
+	if local {
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return Desktop
+		}
+	}
+	return OSC9
+}
+
+// clean strips characters that would end or split an escape sequence.
+func clean(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		if r == ';' {
+			return ','
+		}
+		return r
+	}, s)
+	if r := []rune(s); len(r) > 120 {
+		s = string(r[:119]) + "…"
+	}
+	return s
+}
+
+// quietHours is a daily window, which may wrap past midnight.
+type quietHours struct {
+	start, end int // minutes since midnight
+}
+
+func parseQuietHours(s string) (*quietHours, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid quiet_hours %q (want HH:MM-HH:MM)", s)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours %q (want HH:MM-HH:MM)", s)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours %q (want HH:MM-HH:MM)", s)
+	}
+	return &quietHours{
+		start: start.Hour()*60 + start.Minute(),
+		end:   end.Hour()*60 + end.Minute(),
+	}, nil
+}
+
+func (q *quietHours) contains(t time.Time) bool {
+	if q == nil || q.start == q.end {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if q.start < q.end {
+		return m >= q.start && m < q.end
+	}
+	return m >= q.start || m < q.end
+}
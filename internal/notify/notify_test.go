@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+)
+
+func TestParseMethod(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Method
+		wantErr bool
+	}{
+		{"", Auto, false},
+		{"bell", Bell, false},
+		{"OSC9", OSC9, false},
+		{"off", Off, false},
+		{"email", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMethod(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMethod(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	tests := []struct {
+		window string
+		at     string
+		want   bool
+	}{
+		{"22:00-08:00", "23:30", true},
+		{"22:00-08:00", "07:59", true},
+		{"22:00-08:00", "08:00", false},
+		{"22:00-08:00", "12:00", false},
+		{"12:00-13:30", "12:00", true},
+		{"12:00-13:30", "13:30", false},
+		{"09:00-09:00", "09:00", false},
+	}
+	for _, tt := range tests {
+		q, err := parseQuietHours(tt.window)
+		if err != nil {
+			t.Fatalf("parseQuietHours(%q) = %v", tt.window, err)
+		}
+		at, _ := time.Parse("15:04", tt.at)
+		if got := q.contains(at); got != tt.want {
+			t.Errorf("%s contains %s = %v, want %v", tt.window, tt.at, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"22:00", "late-early", "22:00-8"} {
+		if _, err := parseQuietHours(bad); err == nil {
+			t.Errorf("parseQuietHours(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestQuietHoursTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	cfg := &config.Notifications{Filters: map[string]string{"me": "bell"}, QuietHours: "22:00-08:00"}
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		// 14:00 UTC is 23:00 in Tokyo
+		{"quiet in timezone", tokyo, ""},
+		{"daytime in UTC", time.UTC, "\a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := New(cfg, &out, tt.loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			now := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+			n.now = func() time.Time { return now.In(tt.loc) }
+			if err := n.Notify("me", Notification{Title: "t"}); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	note := Notification{ConversationID: 1, Title: "Ann (#1)", Body: "hi;\x1bthere\nfriend"}
+	tests := []struct {
+		name   string
+		method string
+		filter string
+		tmux   bool
+		want   string
+	}{
+		{"bell", "bell", "me", false, "\a"},
+		{"osc9", "osc9", "me", false, "\x1b]9;Ann (#1): hi, there friend\x07"},
+		{"osc777", "osc777", "me", false, "\x1b]777;notify;Ann (#1);hi, there friend\x07"},
+		{"osc9 in tmux", "osc9", "me", true, "\x1bPtmux;\x1b\x1b]9;Ann (#1): hi, there friend\x07\x1b\\"},
+		{"bell in tmux", "bell", "me", true, "\a"},
+		{"disabled filter", "bell", "all", false, ""},
+		{"off", "off", "me", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tmux {
+				t.Setenv("TMUX", "/tmp/tmux-0/default,1,0")
+			} else {
+				t.Setenv("TMUX", "")
+			}
+			var out bytes.Buffer
+			n, err := New(&config.Notifications{Filters: map[string]string{"me": tt.method}}, &out, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(tt.filter, note); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	for _, cfg := range []*config.Notifications{
+		{Filters: map[string]string{"me": "loud"}},
+		{QuietHours: "night"},
+	} {
+		if _, err := New(cfg, &bytes.Buffer{}, nil); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
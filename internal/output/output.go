@@ -8,6 +8,10 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
 )
 
 type Printer struct {
@@ -31,6 +35,32 @@ func NewPrinter(format string, noColor, quiet bool) *Printer {
 	}
 }
 
+// IsTerminal reports whether output goes to an interactive terminal.
+func (p *Printer) IsTerminal() bool {
+	f, ok := p.Writer.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Width returns the terminal width, or 80 when output is not a terminal.
+func (p *Printer) Width() int {
+	if f, ok := p.Writer.(*os.File); ok {
+		if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+	return 80
+}
+
+// Renderer returns a lipgloss renderer bound to the output writer.
+// Colors are dropped with --no-color or when output is not a terminal.
+func (p *Printer) Renderer() *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(p.Writer)
+	if p.NoColor {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	return r
+}
+
 // PrintTable renders tabular data in the configured format.
 // In quiet mode, only the first column (IDs) is printed.
 func (p *Printer) PrintTable(headers []string, rows [][]string) {
@@ -46,6 +76,10 @@ func (p *Printer) PrintTable(headers []string, rows [][]string) {
 	switch p.Format {
 	case "json":
 		p.tableAsJSON(headers, rows)
+	case "ndjson":
+		for _, m := range rowsAsMaps(headers, rows) {
+			p.PrintNDJSON(m)
+		}
 	case "csv":
 		p.tableAsCSV(headers, rows)
 	default:
@@ -60,14 +94,23 @@ func (p *Printer) PrintJSON(v interface{}) {
 	enc.Encode(v)
 }
 
+// PrintNDJSON outputs v as a single line of JSON, for streaming records.
+func (p *Printer) PrintNDJSON(v interface{}) {
+	json.NewEncoder(p.Writer).Encode(v)
+}
+
 // PrintDetail renders key-value pairs for a single record view.
 func (p *Printer) PrintDetail(pairs []KeyValue) {
-	if p.Format == "json" {
+	if p.Format == "json" || p.Format == "ndjson" {
 		m := make(map[string]string, len(pairs))
 		for _, kv := range pairs {
 			m[kv.Key] = kv.Value
 		}
-		p.PrintJSON(m)
+		if p.Format == "ndjson" {
+			p.PrintNDJSON(m)
+		} else {
+			p.PrintJSON(m)
+		}
 		return
 	}
 
@@ -93,6 +136,10 @@ func (p *Printer) tableAsText(headers []string, rows [][]string) {
 }
 
 func (p *Printer) tableAsJSON(headers []string, rows [][]string) {
+	p.PrintJSON(rowsAsMaps(headers, rows))
+}
+
+func rowsAsMaps(headers []string, rows [][]string) []map[string]string {
 	result := make([]map[string]string, 0, len(rows))
 	for _, row := range rows {
 		m := make(map[string]string, len(headers))
@@ -103,7 +150,7 @@ func (p *Printer) tableAsJSON(headers []string, rows [][]string) {
 		}
 		result = append(result, m)
 	}
-	p.PrintJSON(result)
+	return result
 }
 
 func (p *Printer) tableAsCSV(headers []string, rows [][]string) {
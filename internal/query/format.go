@@ -0,0 +1,94 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// Format renders API predicates back into the query language, e.g. to
+// store a filter saved in Chatwoot as a local view. It is the inverse of
+// Parse except that relative ages have already become dates.
+func Format(preds []sdk.FilterPredicate) string {
+	var b strings.Builder
+	for i, p := range preds {
+		if i > 0 {
+			join := "and"
+			if prev := preds[i-1].QueryOperator; prev != nil && strings.EqualFold(*prev, "or") {
+				join = "or"
+			}
+			b.WriteString(" " + join + " ")
+		}
+		b.WriteString(formatPredicate(p))
+	}
+	return b.String()
+}
+
+func formatPredicate(p sdk.FilterPredicate) string {
+	attr := p.AttributeKey
+	switch p.FilterOperator {
+	case sdk.OpPresent:
+		return attr + " is present"
+	case sdk.OpNotPresent:
+		return attr + " is not present"
+	}
+
+	// The query language has no empty list, so a missing value is written
+	// as an empty string
+	if len(p.Values) == 0 {
+		p.Values = []string{""}
+	}
+	switch p.FilterOperator {
+	case sdk.OpDaysBefore:
+		if p.Values[0] == "" {
+			return attr + ` < ""`
+		}
+		return fmt.Sprintf("%s < %sd", attr, p.Values[0])
+	case sdk.OpContains:
+		return attr + " ~ " + quote(p.Values[0])
+	case sdk.OpNotContains:
+		return attr + " !~ " + quote(p.Values[0])
+	case sdk.OpGreaterThan:
+		return attr + " > " + quote(p.Values[0])
+	case sdk.OpLessThan:
+		return attr + " < " + quote(p.Values[0])
+	}
+
+	negate := p.FilterOperator == sdk.OpNotEqual
+	if len(p.Values) == 1 {
+		if negate {
+			return attr + " != " + quote(p.Values[0])
+		}
+		return attr + " = " + quote(p.Values[0])
+	}
+	values := make([]string, len(p.Values))
+	for i, v := range p.Values {
+		values[i] = quote(v)
+	}
+	op := " in "
+	if negate {
+		op = " not in "
+	}
+	return attr + op + "(" + strings.Join(values, ", ") + ")"
+}
+
+// quote wraps v in quotes unless it lexes as a single word.
+func quote(v string) string {
+	if v != "" {
+		plain := true
+		for i := 0; i < len(v); i++ {
+			if !isWordByte(v[i]) {
+				plain = false
+				break
+			}
+		}
+		if plain {
+			return v
+		}
+	}
+	if strings.Contains(v, `"`) {
+		return "'" + v + "'"
+	}
+	return `"` + v + `"`
+}
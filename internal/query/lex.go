@@ -0,0 +1,92 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the input
+}
+
+func (t token) keyword(k string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, k)
+}
+
+func (t token) symbol(s string) bool {
+	return t.kind == tokSymbol && t.text == s
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return fmt.Sprintf("%q", t.text)
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+func (t token) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s (at position %d)", fmt.Sprintf(format, args...), t.pos+1)
+}
+
+// lex splits input into words, quoted strings and operator symbols.
+func lex(input string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string (at position %d)", i+1)
+			}
+			toks = append(toks, token{kind: tokString, text: input[i+1 : i+1+end], pos: i})
+			i += end + 2
+
+		case c == '!' && i+1 < len(input) && (input[i+1] == '=' || input[i+1] == '~'):
+			toks = append(toks, token{kind: tokSymbol, text: input[i : i+2], pos: i})
+			i += 2
+
+		case strings.IndexByte("=~<>(),", c) >= 0:
+			toks = append(toks, token{kind: tokSymbol, text: string(c), pos: i})
+			i++
+
+		default:
+			start := i
+			for i < len(input) && isWordByte(input[i]) {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected %q (at position %d)", c, i+1)
+			}
+			toks = append(toks, token{kind: tokWord, text: input[start:i], pos: start})
+		}
+	}
+	return toks, nil
+}
+
+func isWordByte(c byte) bool {
+	if c >= 0x80 {
+		return true // part of a UTF-8 sequence
+	}
+	r := rune(c)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:@/+", r)
+}
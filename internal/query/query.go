@@ -0,0 +1,224 @@
+// Package query parses a small filter language into an sdk.Filter, e.g.
+//
+//	status=open and priority in (high,urgent) and created_at>7d
+//
+// Conditions are joined with "and"/"or" (AND binds tighter, no grouping,
+// matching the filter API). Supported forms:
+//
+//	attr = value          attr != value
+//	attr in (a, b)        attr not in (a, b)
+//	attr ~ text           attr !~ text         (contains / does not contain)
+//	attr > value          attr < value         (numbers or dates)
+//	attr is present       attr is not present
+//
+// Values may be quoted with single or double quotes. For > and <, a
+// relative age such as 7d, 12h or 2w becomes the date that long ago, so
+// created_at>7d means "created in the last 7 days".
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// aliases maps friendly attribute names to filter API keys.
+var aliases = map[string]string{
+	"assignee":      "assignee_id",
+	"inbox":         "inbox_id",
+	"team":          "team_id",
+	"id":            "display_id",
+	"label":         "labels",
+	"created":       "created_at",
+	"last_activity": "last_activity_at",
+	"campaign":      "campaign_id",
+	"language":      "browser_language",
+	"country":       "country_code",
+}
+
+var ageRe = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// Parse turns input into a filter. now anchors relative ages.
+func Parse(input string, now time.Time) (*sdk.Filter, error) {
+	toks, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &parser{toks: toks, now: now, filter: sdk.NewFilter()}
+	join := "and"
+	for {
+		if err := p.condition(join); err != nil {
+			return nil, err
+		}
+		if p.done() {
+			return p.filter, nil
+		}
+		t := p.next()
+		switch {
+		case t.keyword("and"):
+			join = "and"
+		case t.keyword("or"):
+			join = "or"
+		default:
+			return nil, t.errorf("expected 'and' or 'or', got %q", t.text)
+		}
+	}
+}
+
+type parser struct {
+	toks   []token
+	pos    int
+	now    time.Time
+	filter *sdk.Filter
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.toks) }
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokEOF, pos: p.endPos()}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) endPos() int {
+	if len(p.toks) == 0 {
+		return 0
+	}
+	last := p.toks[len(p.toks)-1]
+	return last.pos + len(last.text)
+}
+
+// condition parses "attr op value" and adds it to the filter.
+func (p *parser) condition(join string) error {
+	t := p.next()
+	if t.kind != tokWord {
+		return t.errorf("expected an attribute name, got %s", t.describe())
+	}
+	attr := strings.ToLower(t.text)
+	if key, ok := aliases[attr]; ok {
+		attr = key
+	}
+
+	var op string
+	var values []string
+	opTok := p.next()
+	switch {
+	case opTok.symbol("="):
+		op = sdk.OpEqual
+	case opTok.symbol("!="):
+		op = sdk.OpNotEqual
+	case opTok.symbol("~"):
+		op = sdk.OpContains
+	case opTok.symbol("!~"):
+		op = sdk.OpNotContains
+	case opTok.symbol(">"):
+		op = sdk.OpGreaterThan
+	case opTok.symbol("<"):
+		op = sdk.OpLessThan
+	case opTok.keyword("in"):
+		op = sdk.OpEqual
+	case opTok.keyword("not"):
+		if in := p.next(); !in.keyword("in") {
+			return in.errorf("expected 'in' after 'not', got %s", in.describe())
+		}
+		op = sdk.OpNotEqual
+	case opTok.keyword("is"):
+		op = sdk.OpPresent
+		if p.peek().keyword("not") {
+			p.next()
+			op = sdk.OpNotPresent
+		}
+		if pr := p.next(); !pr.keyword("present") {
+			return pr.errorf("expected 'present', got %s", pr.describe())
+		}
+		p.add(join, attr, op, nil)
+		return nil
+	default:
+		return opTok.errorf("expected an operator after %q, got %s", t.text, opTok.describe())
+	}
+
+	if opTok.keyword("in") || opTok.keyword("not") {
+		list, err := p.list()
+		if err != nil {
+			return err
+		}
+		values = list
+	} else {
+		v := p.next()
+		if v.kind != tokWord && v.kind != tokString {
+			return v.errorf("expected a value, got %s", v.describe())
+		}
+		values = []string{v.text}
+	}
+
+	if op == sdk.OpGreaterThan || op == sdk.OpLessThan {
+		values[0] = p.resolveAge(values[0])
+	}
+	p.add(join, attr, op, values)
+	return nil
+}
+
+// list parses "(a, b, c)".
+func (p *parser) list() ([]string, error) {
+	if t := p.next(); !t.symbol("(") {
+		return nil, t.errorf("expected '(', got %s", t.describe())
+	}
+	var values []string
+	for {
+		v := p.next()
+		if v.kind != tokWord && v.kind != tokString {
+			return nil, v.errorf("expected a value, got %s", v.describe())
+		}
+		values = append(values, v.text)
+
+		sep := p.next()
+		if sep.symbol(")") {
+			return values, nil
+		}
+		if !sep.symbol(",") {
+			return nil, sep.errorf("expected ',' or ')', got %s", sep.describe())
+		}
+	}
+}
+
+func (p *parser) add(join, attr, op string, values []string) {
+	if join == "or" {
+		p.filter.Or(attr, op, values...)
+	} else {
+		p.filter.And(attr, op, values...)
+	}
+}
+
+// resolveAge turns "7d" into the date 7 days before now.
+func (p *parser) resolveAge(v string) string {
+	m := ageRe.FindStringSubmatch(v)
+	if m == nil {
+		return v
+	}
+	n, _ := strconv.Atoi(m[1])
+	d := time.Duration(n) * time.Hour
+	switch m[2] {
+	case "d":
+		d *= 24
+	case "w":
+		d *= 24 * 7
+	}
+	return p.now.Add(-d).Format("2006-01-02")
+}
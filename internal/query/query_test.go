@@ -0,0 +1,166 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// describe renders predicates as "attribute operator values join".
+func describe(preds []sdk.FilterPredicate) []string {
+	var out []string
+	for _, p := range preds {
+		s := p.AttributeKey + " " + p.FilterOperator + " " + strings.Join(p.Values, ",")
+		if p.QueryOperator != nil {
+			s += " " + *p.QueryOperator
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"status=open", []string{"status " + sdk.OpEqual + " open"}},
+		{"assignee != 3", []string{"assignee_id " + sdk.OpNotEqual + " 3"}},
+		{"priority in (high, urgent)", []string{"priority " + sdk.OpEqual + " high,urgent"}},
+		{"label NOT IN (spam,'out of office')", []string{"labels " + sdk.OpNotEqual + " spam,out of office"}},
+		{`email ~ "@example.com"`, []string{"email " + sdk.OpContains + " @example.com"}},
+		{"name !~ bot", []string{"name " + sdk.OpNotContains + " bot"}},
+		{"created_at>7d", []string{"created_at " + sdk.OpGreaterThan + " 2026-03-03"}},
+		{"last_activity < 2w", []string{"last_activity_at " + sdk.OpLessThan + " 2026-02-24"}},
+		{"created > 36h", []string{"created_at " + sdk.OpGreaterThan + " 2026-03-09"}},
+		{"id > 100", []string{"display_id " + sdk.OpGreaterThan + " 100"}},
+		{"team is present", []string{"team_id " + sdk.OpPresent + " "}},
+		{"team is not present", []string{"team_id " + sdk.OpNotPresent + " "}},
+		{"status=open and inbox=2 or label=vip", []string{
+			"status " + sdk.OpEqual + " open and",
+			"inbox_id " + sdk.OpEqual + " 2 or",
+			"labels " + sdk.OpEqual + " vip",
+		}},
+		{"city = 'São Paulo'", []string{"city " + sdk.OpEqual + " São Paulo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			f, err := Parse(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describe(f.Predicates()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "empty query"},
+		{"   ", "empty query"},
+		{"status", "expected an operator after \"status\", got end of query (at position 7)"},
+		{"status =", "expected a value, got end of query"},
+		{"= open", "expected an attribute name, got '=' (at position 1)"},
+		{"status=open inbox=2", "expected 'and' or 'or', got \"inbox\" (at position 13)"},
+		{"status=open and", "expected an attribute name, got end of query"},
+		{"label in vip", "expected '(', got 'vip'"},
+		{"label in (a b)", "expected ',' or ')', got 'b'"},
+		{"label in ()", "expected a value, got ')'"},
+		{"label not (a)", "expected 'in' after 'not'"},
+		{"team is there", "expected 'present', got 'there'"},
+		{`name = "bob`, "unterminated string (at position 8)"},
+		{"status = open;", "unexpected ';' (at position 14)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input, now)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	tests := []string{
+		"status = open",
+		"assignee_id != 3 or team_id is not present",
+		"priority in (high, urgent) and labels not in (spam, \"out of office\")",
+		`email ~ @example.com and name !~ "two words" or name !~ 'say "hi"'`,
+		"created_at > 2026-03-03 and display_id < 100",
+		"team_id is present",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			f, err := Parse(input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Format(f.Predicates()); got != input {
+				t.Errorf("Format() = %q, want %q", got, input)
+			}
+		})
+	}
+}
+
+func TestFormatEmptyValues(t *testing.T) {
+	tests := []struct {
+		op   string
+		want string
+	}{
+		{sdk.OpEqual, `labels = ""`},
+		{sdk.OpNotEqual, `labels != ""`},
+		{sdk.OpContains, `labels ~ ""`},
+		{sdk.OpGreaterThan, `labels > ""`},
+		{sdk.OpDaysBefore, `labels < ""`},
+		{sdk.OpPresent, "labels is present"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			got := Format([]sdk.FilterPredicate{{AttributeKey: "labels", FilterOperator: tt.op}})
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			if _, err := Parse(got, now); err != nil {
+				t.Errorf("Parse(%q): %v", got, err)
+			}
+		})
+	}
+}
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"a=b", []string{"a", "=", "b"}},
+		{"a!=b and c!~'d e'", []string{"a", "!=", "b", "and", "c", "!~", "d e"}},
+		{"x in (1,2)", []string{"x", "in", "(", "1", ",", "2", ")"}},
+		{"email=a.b+c@x.io", []string{"email", "=", "a.b+c@x.io"}},
+		{"a > 2026-03-10", []string{"a", ">", "2026-03-10"}},
+		{`a = ""`, []string{"a", "=", ""}},
+	}
+	for _, tt := range tests {
+		toks, err := lex(tt.input)
+		if err != nil {
+			t.Fatalf("lex(%q): %v", tt.input, err)
+		}
+		var got []string
+		for _, tok := range toks {
+			got = append(got, tok.text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lex(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
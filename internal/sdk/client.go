@@ -111,6 +111,19 @@ func (c *Client) GetRaw(path string, params url.Values, v interface{}) error {
 	return c.do(req, v)
 }
 
+// PostRaw makes a POST request to a non-account-scoped path (e.g. /api/v1/profile/availability).
+func (c *Client) PostRaw(path string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("api_access_token", c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, v)
+}
+
 func (c *Client) Post(path string, body io.Reader, v interface{}) error {
 	req, err := c.request(http.MethodPost, path, body)
 	if err != nil {
@@ -153,6 +166,11 @@ func (c *Client) Labels(conversationID int) *LabelsService {
 	return &LabelsService{client: c, conversationID: conversationID}
 }
 
+// AccountLabels returns the service for the labels defined in the account
+func (c *Client) AccountLabels() *AccountLabelsService {
+	return &AccountLabelsService{client: c}
+}
+
 // Contacts returns the contacts service
 func (c *Client) Contacts() *ContactsService {
 	return &ContactsService{client: c}
@@ -173,6 +191,21 @@ func (c *Client) Teams() *TeamsService {
 	return &TeamsService{client: c}
 }
 
+// CustomFilters returns the saved filters service
+func (c *Client) CustomFilters() *CustomFiltersService {
+	return &CustomFiltersService{client: c}
+}
+
+// Notifications returns the notifications service
+func (c *Client) Notifications() *NotificationsService {
+	return &NotificationsService{client: c}
+}
+
+// Search returns the conversation and message search service
+func (c *Client) Search() *SearchService {
+	return &SearchService{client: c}
+}
+
 // Profile returns the profile service
 func (c *Client) Profile() *ProfileService {
 	return &ProfileService{client: c}
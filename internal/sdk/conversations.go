@@ -25,6 +25,7 @@ type Conversation struct {
 	LastActivityAt       int64                  `json:"last_activity_at"`
 	ContactLastSeenAt    int64                  `json:"contact_last_seen_at"`
 	AgentLastSeenAt      int64                  `json:"agent_last_seen_at"`
+	WaitingSince         int64                  `json:"waiting_since,omitempty"`
 	Meta                 ConversationMeta       `json:"meta"`
 	Labels               []string               `json:"labels"`
 	AdditionalAttributes map[string]interface{} `json:"additional_attributes"`
@@ -158,6 +159,31 @@ func (s *ConversationsService) ToggleStatus(id int, status string, snoozedUntil
 	return &resp, nil
 }
 
+// Priorities accepted by TogglePriority, highest first. "none" clears it.
+var Priorities = []string{"urgent", "high", "medium", "low", "none"}
+
+type TogglePriorityRequest struct {
+	Priority *string `json:"priority"`
+}
+
+// TogglePriority sets a conversation's priority. Pass "none" to clear it.
+func (s *ConversationsService) TogglePriority(id int, priority string) error {
+	var body TogglePriorityRequest
+	if priority != "none" {
+		body.Priority = &priority
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Post(fmt.Sprintf("/conversations/%d/toggle_priority", id), bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to set priority: %w", err)
+	}
+	return nil
+}
+
 type AssignRequest struct {
 	AssigneeID int `json:"assignee_id"`
 	TeamID     int `json:"team_id,omitempty"`
@@ -181,3 +207,18 @@ func (s *ConversationsService) Assign(id int, assigneeID int, teamID int) (*Conv
 
 	return &conv, nil
 }
+
+type AssignTeamRequest struct {
+	TeamID int `json:"team_id"`
+}
+
+// AssignTeam sets the conversation's team; 0 removes it. The API only
+// looks at team_id when assignee_id is absent, so this is separate from
+// Assign.
+func (s *ConversationsService) AssignTeam(id int, teamID int) error {
+	jsonBody, err := json.Marshal(AssignTeamRequest{TeamID: teamID})
+	if err != nil {
+		return err
+	}
+	return s.client.Post(fmt.Sprintf("/conversations/%d/assignments", id), bytes.NewReader(jsonBody), nil)
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type CustomFiltersService struct {
+	client *Client
+}
+
+// CustomFilter is a filter saved in Chatwoot, shown as a folder in the
+// web app's sidebar.
+type CustomFilter struct {
+	ID         int               `json:"id"`
+	Name       string            `json:"name"`
+	FilterType string            `json:"filter_type"`
+	Query      CustomFilterQuery `json:"query"`
+}
+
+type CustomFilterQuery struct {
+	Payload []FilterPredicate `json:"payload"`
+}
+
+// List returns saved filters of the given type (conversation, contact, report).
+func (s *CustomFiltersService) List(filterType string) ([]CustomFilter, error) {
+	params := url.Values{}
+	params.Set("filter_type", filterType)
+
+	var filters []CustomFilter
+	if err := s.client.Get("/custom_filters", params, &filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+type createCustomFilterRequest struct {
+	CustomFilter struct {
+		Name       string            `json:"name"`
+		FilterType string            `json:"filter_type"`
+		Query      CustomFilterQuery `json:"query"`
+	} `json:"custom_filter"`
+}
+
+// Create saves a conversation filter in Chatwoot.
+func (s *CustomFiltersService) Create(name string, f *Filter) (*CustomFilter, error) {
+	var body createCustomFilterRequest
+	body.CustomFilter.Name = name
+	body.CustomFilter.FilterType = "conversation"
+	body.CustomFilter.Query.Payload = f.Predicates()
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter CustomFilter
+	if err := s.client.Post("/custom_filters", bytes.NewReader(jsonBody), &filter); err != nil {
+		return nil, fmt.Errorf("failed to save filter %q: %w", name, err)
+	}
+	return &filter, nil
+}
+
+func (s *CustomFiltersService) Delete(id int) error {
+	if err := s.client.Delete(fmt.Sprintf("/custom_filters/%d", id), nil); err != nil {
+		return fmt.Errorf("failed to delete filter %d: %w", id, err)
+	}
+	return nil
+}
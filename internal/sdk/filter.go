@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Filter operators understood by POST /conversations/filter.
+const (
+	OpEqual       = "equal_to"
+	OpNotEqual    = "not_equal_to"
+	OpContains    = "contains"
+	OpNotContains = "does_not_contain"
+	OpPresent     = "is_present"
+	OpNotPresent  = "is_not_present"
+	OpGreaterThan = "is_greater_than"
+	OpLessThan    = "is_less_than"
+	OpDaysBefore  = "days_before"
+)
+
+// FilterPredicate is one condition of a conversation filter. QueryOperator
+// joins it to the next predicate and is nil on the last one.
+type FilterPredicate struct {
+	AttributeKey   string       `json:"attribute_key"`
+	FilterOperator string       `json:"filter_operator"`
+	Values         FilterValues `json:"values"`
+	QueryOperator  *string      `json:"query_operator"`
+}
+
+// FilterValues decodes the values of saved filters, which the web app
+// stores as strings, numbers or {"id": ..., "name": ...} objects.
+type FilterValues []string
+
+func (v *FilterValues) UnmarshalJSON(data []byte) error {
+	var raw []interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// A single value rather than a list
+		var one interface{}
+		if err := json.Unmarshal(data, &one); err != nil {
+			return err
+		}
+		raw = []interface{}{one}
+	}
+
+	values := make(FilterValues, 0, len(raw))
+	for _, r := range raw {
+		switch x := r.(type) {
+		case nil:
+		case string:
+			values = append(values, x)
+		case float64:
+			values = append(values, strconv.FormatFloat(x, 'f', -1, 64))
+		case map[string]interface{}:
+			if id, ok := x["id"]; ok {
+				values = append(values, fmt.Sprint(id))
+			}
+		default:
+			values = append(values, fmt.Sprint(x))
+		}
+	}
+	*v = values
+	return nil
+}
+
+// Filter builds the predicate list for the filter endpoint. Chatwoot
+// evaluates predicates left to right with SQL precedence (AND binds tighter
+// than OR) and has no grouping.
+//
+//	sdk.NewFilter().
+//		Where("status", sdk.OpEqual, "open").
+//		And("priority", sdk.OpEqual, "high", "urgent")
+type Filter struct {
+	predicates []FilterPredicate
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Where adds the first predicate. On a non-empty filter it behaves like And.
+func (f *Filter) Where(attribute, operator string, values ...string) *Filter {
+	return f.add("and", attribute, operator, values)
+}
+
+func (f *Filter) And(attribute, operator string, values ...string) *Filter {
+	return f.add("and", attribute, operator, values)
+}
+
+func (f *Filter) Or(attribute, operator string, values ...string) *Filter {
+	return f.add("or", attribute, operator, values)
+}
+
+func (f *Filter) add(join, attribute, operator string, values []string) *Filter {
+	if n := len(f.predicates); n > 0 {
+		f.predicates[n-1].QueryOperator = &join
+	}
+	if values == nil {
+		values = []string{}
+	}
+	f.predicates = append(f.predicates, FilterPredicate{
+		AttributeKey:   attribute,
+		FilterOperator: operator,
+		Values:         values,
+	})
+	return f
+}
+
+// Predicates returns the filter as sent to the API.
+func (f *Filter) Predicates() []FilterPredicate {
+	return f.predicates
+}
+
+// Len reports the number of predicates.
+func (f *Filter) Len() int {
+	return len(f.predicates)
+}
+
+type FilterRequest struct {
+	Payload []FilterPredicate `json:"payload"`
+}
+
+type ConversationFilterResponse struct {
+	Meta struct {
+		AllCount        int `json:"all_count"`
+		MineCount       int `json:"mine_count"`
+		UnassignedCount int `json:"unassigned_count"`
+	} `json:"meta"`
+	Payload []Conversation `json:"payload"`
+}
+
+// Filter returns one page of conversations matching f.
+func (s *ConversationsService) Filter(f *Filter, page int) (*ConversationFilterResponse, error) {
+	jsonBody, err := json.Marshal(FilterRequest{Payload: f.Predicates()})
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/conversations/filter"
+	if page > 0 {
+		path += "?" + url.Values{"page": {strconv.Itoa(page)}}.Encode()
+	}
+
+	var resp ConversationFilterResponse
+	if err := s.client.Post(path, bytes.NewReader(jsonBody), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
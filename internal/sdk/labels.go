@@ -44,3 +44,29 @@ func (s *LabelsService) Add(labels []string) ([]string, error) {
 
 	return resp.Payload, nil
 }
+
+type AccountLabelsService struct {
+	client *Client
+}
+
+// AccountLabel is a label defined in the account, which conversations can
+// be tagged with.
+type AccountLabel struct {
+	ID            int    `json:"id"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Color         string `json:"color"`
+	ShowOnSidebar bool   `json:"show_on_sidebar"`
+}
+
+type AccountLabelsResponse struct {
+	Payload []AccountLabel `json:"payload"`
+}
+
+func (s *AccountLabelsService) List() ([]AccountLabel, error) {
+	var resp AccountLabelsResponse
+	if err := s.client.Get("/labels", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Payload, nil
+}
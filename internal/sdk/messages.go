@@ -15,6 +15,7 @@ type MessagesService struct {
 
 type Message struct {
 	ID                int                    `json:"id"`
+	ConversationID    int                    `json:"conversation_id,omitempty"`
 	Content           string                 `json:"content"`
 	ContentType       string                 `json:"content_type"`
 	ContentAttributes map[string]interface{} `json:"content_attributes"`
@@ -63,7 +64,19 @@ func (s *MessagesService) List(beforeID int) (*MessagesListResponse, error) {
 	if beforeID > 0 {
 		params.Set("before", strconv.Itoa(beforeID))
 	}
+	return s.list(params)
+}
+
+// ListAfter returns messages newer than afterID, oldest first.
+func (s *MessagesService) ListAfter(afterID int) (*MessagesListResponse, error) {
+	params := url.Values{}
+	if afterID > 0 {
+		params.Set("after", strconv.Itoa(afterID))
+	}
+	return s.list(params)
+}
 
+func (s *MessagesService) list(params url.Values) (*MessagesListResponse, error) {
 	path := fmt.Sprintf("/conversations/%d/messages", s.conversationID)
 	var resp MessagesListResponse
 	if err := s.client.Get(path, params, &resp); err != nil {
@@ -73,6 +86,34 @@ func (s *MessagesService) List(beforeID int) (*MessagesListResponse, error) {
 	return &resp, nil
 }
 
+// Latest returns up to n of the most recent messages in chronological order,
+// following the before cursor until enough messages are collected.
+// n <= 0 fetches the entire history.
+func (s *MessagesService) Latest(n int) ([]Message, error) {
+	var all []Message
+	beforeID := 0
+	for n <= 0 || len(all) < n {
+		resp, err := s.List(beforeID)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Payload) == 0 {
+			break
+		}
+		oldest := resp.Payload[0].ID
+		if beforeID > 0 && oldest >= beforeID {
+			break // server ignored the cursor; the page repeats what we have
+		}
+		all = append(resp.Payload, all...)
+		beforeID = oldest
+	}
+
+	if n > 0 && len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return all, nil
+}
+
 type CreateMessageRequest struct {
 	Content     string `json:"content"`
 	MessageType string `json:"message_type,omitempty"`
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// messageServer serves messages 1..total in pages of 20, newest first like
+// Chatwoot. With ignoreBefore it always returns the newest page.
+func messageServer(total int, ignoreBefore bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		end := total + 1
+		if before, _ := strconv.Atoi(r.URL.Query().Get("before")); before > 0 && !ignoreBefore {
+			end = before
+		}
+		var resp MessagesListResponse
+		for id := max(1, end-20); id < end; id++ {
+			resp.Payload = append(resp.Payload, Message{ID: id})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		ignoreBefore bool
+		n            int
+		wantFirst    int
+		wantLen      int
+	}{
+		{"all", 50, false, 0, 1, 50},
+		{"last few", 50, false, 5, 46, 5},
+		{"across pages", 50, false, 30, 21, 30},
+		{"more than exist", 10, false, 30, 1, 10},
+		{"cursor ignored", 50, true, 0, 31, 20},
+		{"cursor ignored with n", 50, true, 30, 31, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := messageServer(tt.total, tt.ignoreBefore)
+			defer srv.Close()
+
+			msgs, err := NewClient(srv.URL, "", 1).Messages(1).Latest(tt.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(msgs) != tt.wantLen {
+				t.Fatalf("got %d messages, want %d", len(msgs), tt.wantLen)
+			}
+			for i, m := range msgs {
+				if want := tt.wantFirst + i; m.ID != want {
+					t.Fatalf("message %d has ID %d, want %d (duplicated or out of order)", i, m.ID, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type NotificationsService struct {
+	client *Client
+}
+
+type Notification struct {
+	ID               int                `json:"id"`
+	NotificationType string             `json:"notification_type"`
+	PushMessageTitle string             `json:"push_message_title"`
+	PrimaryActorType string             `json:"primary_actor_type"`
+	PrimaryActorID   int                `json:"primary_actor_id"`
+	PrimaryActor     *NotificationActor `json:"primary_actor"`
+	ReadAt           interface{}        `json:"read_at"` // null when unread; the API has used both epoch and ISO forms
+	CreatedAt        int64              `json:"created_at"`
+	LastActivityAt   int64              `json:"last_activity_at,omitempty"`
+}
+
+// NotificationActor is the subset of the primary actor (a conversation or
+// message) needed to find the conversation a notification refers to.
+type NotificationActor struct {
+	ID             int `json:"id"`
+	ConversationID int `json:"conversation_id,omitempty"`
+}
+
+// IsRead reports whether the notification has been read.
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// ConversationID returns the display ID of the conversation the
+// notification refers to, or 0 if there is none.
+func (n Notification) ConversationID() int {
+	if n.PrimaryActor != nil {
+		if n.PrimaryActor.ConversationID != 0 {
+			return n.PrimaryActor.ConversationID
+		}
+		if n.PrimaryActorType == "Conversation" {
+			return n.PrimaryActor.ID
+		}
+	}
+	if n.PrimaryActorType == "Conversation" {
+		return n.PrimaryActorID
+	}
+	return 0
+}
+
+type NotificationsListResponse struct {
+	Data struct {
+		Meta struct {
+			UnreadCount int `json:"unread_count"`
+			Count       int `json:"count"`
+		} `json:"meta"`
+		Payload []Notification `json:"payload"`
+	} `json:"data"`
+}
+
+func (s *NotificationsService) List(page int) (*NotificationsListResponse, error) {
+	params := url.Values{}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+
+	var resp NotificationsListResponse
+	if err := s.client.Get("/notifications", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// MarkRead marks one notification as read.
+func (s *NotificationsService) MarkRead(id int) error {
+	path := fmt.Sprintf("/notifications/%d", id)
+	if err := s.client.Patch(path, nil, nil); err != nil {
+		return fmt.Errorf("failed to mark notification %d as read: %w", id, err)
+	}
+	return nil
+}
+
+// MarkAllRead marks every notification as read.
+func (s *NotificationsService) MarkAllRead() error {
+	if err := s.client.Post("/notifications/read_all", nil, nil); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+func (s *NotificationsService) UnreadCount() (int, error) {
+	var count int
+	if err := s.client.Get("/notifications/unread_count", nil, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
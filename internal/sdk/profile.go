@@ -1,5 +1,11 @@
 package sdk
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 type ProfileService struct {
 	client *Client
 }
@@ -12,6 +18,7 @@ type ProfileResponse struct {
 	Role               string `json:"role"`
 	Thumbnail          string `json:"thumbnail"`
 	AccountID          int    `json:"account_id"`
+	PubsubToken        string `json:"pubsub_token"`
 	UISettings         map[string]interface{} `json:"ui_settings"`
 }
 
@@ -23,3 +30,32 @@ func (s *ProfileService) Get() (*ProfileResponse, error) {
 	}
 	return &profile, nil
 }
+
+// Availability values accepted by SetAvailability.
+var AvailabilityStatuses = []string{"online", "busy", "offline"}
+
+type availabilityRequest struct {
+	Profile struct {
+		Availability string `json:"availability"`
+		AccountID    int    `json:"account_id"`
+	} `json:"profile"`
+}
+
+// SetAvailability changes the current user's availability (online, busy or
+// offline) in the client's account and returns the updated profile.
+func (s *ProfileService) SetAvailability(status string) (*ProfileResponse, error) {
+	var body availabilityRequest
+	body.Profile.Availability = status
+	body.Profile.AccountID = s.client.AccountID
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile ProfileResponse
+	if err := s.client.PostRaw("/api/v1/profile/availability", bytes.NewReader(jsonBody), &profile); err != nil {
+		return nil, fmt.Errorf("failed to set availability: %w", err)
+	}
+	return &profile, nil
+}
@@ -0,0 +1,257 @@
+// Package realtime subscribes to Chatwoot's ActionCable endpoint (/cable)
+// and delivers account events such as new messages, status changes and
+// typing indicators as they happen.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"golang.org/x/net/websocket"
+)
+
+// Event names broadcast by Chatwoot on the RoomChannel.
+const (
+	MessageCreated            = "message.created"
+	MessageUpdated            = "message.updated"
+	ConversationCreated       = "conversation.created"
+	ConversationStatusChanged = "conversation.status_changed"
+	ConversationUpdated       = "conversation.updated"
+	AssigneeChanged           = "assignee.changed"
+	TypingOn                  = "conversation.typing_on"
+	TypingOff                 = "conversation.typing_off"
+	NotificationCreated       = "notification.created"
+	NotificationUpdated       = "notification.updated"
+	NotificationDeleted       = "notification.deleted"
+)
+
+// Connection lifecycle events, emitted by the client itself.
+const (
+	Connected    = "realtime.connected"
+	Disconnected = "realtime.disconnected"
+)
+
+const (
+	// ActionCable pings every 3 seconds; missing several means the
+	// connection is dead even if TCP hasn't noticed yet.
+	readTimeout      = 15 * time.Second
+	presenceInterval = 20 * time.Second
+	maxBackoff       = 30 * time.Second
+)
+
+// Event is a single broadcast from the server.
+type Event struct {
+	Name string          `json:"event"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Message decodes the payload of message.* events.
+func (e Event) Message() (*sdk.Message, error) {
+	var msg sdk.Message
+	if err := json.Unmarshal(e.Data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", e.Name, err)
+	}
+	return &msg, nil
+}
+
+// Conversation decodes the payload of conversation.* and assignee.changed events.
+func (e Event) Conversation() (*sdk.Conversation, error) {
+	var conv sdk.Conversation
+	if err := json.Unmarshal(e.Data, &conv); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", e.Name, err)
+	}
+	return &conv, nil
+}
+
+// Typing is the payload of typing_on/typing_off events.
+type Typing struct {
+	Conversation sdk.Conversation   `json:"conversation"`
+	User         *sdk.MessageSender `json:"user"`
+	IsPrivate    bool               `json:"is_private"`
+}
+
+func (e Event) Typing() (*Typing, error) {
+	var t Typing
+	if err := json.Unmarshal(e.Data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", e.Name, err)
+	}
+	return &t, nil
+}
+
+// ErrUnauthorized is returned by Run when the server rejects the pubsub token.
+var ErrUnauthorized = errors.New("realtime: connection rejected by server")
+
+type Client struct {
+	URL         string // ws(s)://host/cable
+	Origin      string
+	PubsubToken string
+	AccountID   int
+	UserID      int
+}
+
+// New creates a client for the instance at baseURL. The pubsub token comes
+// from the user's profile.
+func New(baseURL, pubsubToken string, accountID, userID int) *Client {
+	base := strings.TrimSuffix(baseURL, "/")
+	wsURL := "ws" + strings.TrimPrefix(base, "http") + "/cable"
+	return &Client{
+		URL:         wsURL,
+		Origin:      base,
+		PubsubToken: pubsubToken,
+		AccountID:   accountID,
+		UserID:      userID,
+	}
+}
+
+// frame is an ActionCable protocol message.
+type frame struct {
+	Type       string          `json:"type,omitempty"`
+	Identifier string          `json:"identifier,omitempty"`
+	Message    json.RawMessage `json:"message,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	Reconnect  *bool           `json:"reconnect,omitempty"`
+}
+
+type command struct {
+	Command    string `json:"command"`
+	Identifier string `json:"identifier"`
+	Data       string `json:"data,omitempty"`
+}
+
+// Run connects, subscribes and sends events to out until ctx is cancelled,
+// reconnecting with exponential backoff when the connection drops. It only
+// returns early if the server refuses the subscription.
+func (c *Client) Run(ctx context.Context, out chan<- Event) error {
+	backoff := time.Second
+	for {
+		subscribed, err := c.session(ctx, out)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, ErrUnauthorized) {
+			return err
+		}
+		send(ctx, out, Event{Name: Disconnected})
+
+		if subscribed {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (c *Client) identifier() string {
+	id, _ := json.Marshal(map[string]interface{}{
+		"channel":      "RoomChannel",
+		"pubsub_token": c.PubsubToken,
+		"account_id":   c.AccountID,
+		"user_id":      c.UserID,
+	})
+	return string(id)
+}
+
+// session runs a single connection until it fails. subscribed reports
+// whether the server confirmed the subscription before the failure.
+func (c *Client) session(ctx context.Context, out chan<- Event) (subscribed bool, err error) {
+	cfg, err := websocket.NewConfig(c.URL, c.Origin)
+	if err != nil {
+		return false, err
+	}
+	conn, err := cfg.DialContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	var mu sync.Mutex
+	write := func(cmd command) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return websocket.JSON.Send(conn, cmd)
+	}
+
+	identifier := c.identifier()
+	for {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		var f frame
+		if err := websocket.JSON.Receive(conn, &f); err != nil {
+			return subscribed, err
+		}
+
+		switch f.Type {
+		case "welcome":
+			if err := write(command{Command: "subscribe", Identifier: identifier}); err != nil {
+				return subscribed, err
+			}
+		case "confirm_subscription":
+			subscribed = true
+			send(ctx, out, Event{Name: Connected})
+			go c.keepPresence(ctx, done, write, identifier)
+		case "reject_subscription":
+			return subscribed, ErrUnauthorized
+		case "disconnect":
+			if f.Reconnect != nil && !*f.Reconnect {
+				return subscribed, ErrUnauthorized
+			}
+			return subscribed, fmt.Errorf("server closed connection: %s", f.Reason)
+		case "ping":
+			// keepalive only; the read deadline above handles staleness
+		default:
+			if len(f.Message) == 0 {
+				continue
+			}
+			var ev Event
+			if err := json.Unmarshal(f.Message, &ev); err != nil || ev.Name == "" {
+				continue
+			}
+			send(ctx, out, ev)
+		}
+	}
+}
+
+// keepPresence tells Chatwoot the agent is active, as the web dashboard does,
+// so availability stays accurate while the client is connected.
+func (c *Client) keepPresence(ctx context.Context, done <-chan struct{}, write func(command) error, identifier string) {
+	data, _ := json.Marshal(map[string]string{"action": "update_presence"})
+	ticker := time.NewTicker(presenceInterval)
+	defer ticker.Stop()
+	for {
+		if err := write(command{Command: "message", Identifier: identifier, Data: string(data)}); err != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func send(ctx context.Context, out chan<- Event, ev Event) {
+	select {
+	case out <- ev:
+	case <-ctx.Done():
+	}
+}
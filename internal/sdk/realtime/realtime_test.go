@@ -0,0 +1,219 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// cable is a stand-in for Chatwoot's /cable endpoint. Each connection gets
+// a welcome and then whatever the session func sends.
+type cable struct {
+	t           *testing.T
+	connections atomic.Int32
+	commands    chan command
+	closed      chan int // connections the client closed
+	session     func(conn *websocket.Conn, n int)
+}
+
+func newCable(t *testing.T, session func(conn *websocket.Conn, n int)) (*cable, *Client) {
+	c := &cable{t: t, commands: make(chan command, 64), closed: make(chan int, 8), session: session}
+	srv := httptest.NewServer(websocket.Handler(c.serve))
+	t.Cleanup(srv.Close)
+	return c, New(srv.URL, "tok", 1, 2)
+}
+
+func (c *cable) serve(conn *websocket.Conn) {
+	n := int(c.connections.Add(1))
+	go func() {
+		for {
+			var cmd command
+			if err := websocket.JSON.Receive(conn, &cmd); err != nil {
+				c.closed <- n
+				return
+			}
+			c.commands <- cmd
+		}
+	}()
+	websocket.JSON.Send(conn, frame{Type: "welcome"})
+	c.session(conn, n)
+}
+
+// expectCommand waits for the client to send a command.
+func (c *cable) expectCommand(name string) command {
+	c.t.Helper()
+	select {
+	case cmd := <-c.commands:
+		if cmd.Command != name {
+			c.t.Fatalf("command = %q, want %q", cmd.Command, name)
+		}
+		return cmd
+	case <-time.After(5 * time.Second):
+		c.t.Fatalf("no %s command", name)
+	}
+	return command{}
+}
+
+func confirm(conn *websocket.Conn) {
+	websocket.JSON.Send(conn, frame{Type: "confirm_subscription"})
+}
+
+func broadcast(conn *websocket.Conn, name string, data any) {
+	msg, _ := json.Marshal(map[string]any{"event": name, "data": data})
+	websocket.JSON.Send(conn, frame{Identifier: "x", Message: msg})
+}
+
+func expectEvent(t *testing.T, out <-chan Event, name string) Event {
+	t.Helper()
+	select {
+	case ev := <-out:
+		if ev.Name != name {
+			t.Fatalf("event = %q, want %q", ev.Name, name)
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no %s event", name)
+	}
+	return Event{}
+}
+
+func TestSubscribeAndReceive(t *testing.T) {
+	hold := make(chan struct{})
+	defer close(hold)
+	cable, client := newCable(t, func(conn *websocket.Conn, n int) {
+		confirm(conn)
+		websocket.JSON.Send(conn, frame{Type: "ping", Message: json.RawMessage(`1700000000`)})
+		broadcast(conn, MessageCreated, map[string]any{"id": 5, "content": "hi", "conversation_id": 9})
+		broadcast(conn, ConversationStatusChanged, map[string]any{"id": 9, "status": "resolved"})
+		broadcast(conn, TypingOn, map[string]any{"conversation": map[string]any{"id": 9}, "user": map[string]any{"name": "Ann"}})
+		<-hold
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan Event, 16)
+	go client.Run(ctx, out)
+
+	sub := cable.expectCommand("subscribe")
+	var id map[string]any
+	if err := json.Unmarshal([]byte(sub.Identifier), &id); err != nil {
+		t.Fatalf("identifier: %v", err)
+	}
+	if id["channel"] != "RoomChannel" || id["pubsub_token"] != "tok" || id["account_id"] != 1.0 || id["user_id"] != 2.0 {
+		t.Errorf("identifier = %v", id)
+	}
+
+	expectEvent(t, out, Connected)
+	presence := cable.expectCommand("message")
+	if presence.Identifier != sub.Identifier || presence.Data != `{"action":"update_presence"}` {
+		t.Errorf("presence = %+v", presence)
+	}
+
+	msg, err := expectEvent(t, out, MessageCreated).Message()
+	if err != nil || msg.ID != 5 || msg.Content != "hi" || msg.ConversationID != 9 {
+		t.Errorf("Message() = %+v, %v", msg, err)
+	}
+	conv, err := expectEvent(t, out, ConversationStatusChanged).Conversation()
+	if err != nil || conv.ID != 9 || conv.Status != "resolved" {
+		t.Errorf("Conversation() = %+v, %v", conv, err)
+	}
+	typing, err := expectEvent(t, out, TypingOn).Typing()
+	if err != nil || typing.Conversation.ID != 9 || typing.User == nil || typing.User.Name != "Ann" {
+		t.Errorf("Typing() = %+v, %v", typing, err)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	hold := make(chan struct{})
+	defer close(hold)
+	cable, client := newCable(t, func(conn *websocket.Conn, n int) {
+		confirm(conn)
+		if n == 1 {
+			return // drop the first connection
+		}
+		broadcast(conn, MessageCreated, map[string]any{"id": 1})
+		<-hold
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan Event, 16)
+	go client.Run(ctx, out)
+
+	expectEvent(t, out, Connected)
+	expectEvent(t, out, Disconnected)
+	expectEvent(t, out, Connected)
+	expectEvent(t, out, MessageCreated)
+	if n := cable.connections.Load(); n != 2 {
+		t.Errorf("connections = %d, want 2", n)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	reconnect := false
+	tests := []struct {
+		name  string
+		frame frame
+	}{
+		{"reject subscription", frame{Type: "reject_subscription"}},
+		{"unauthorized disconnect", frame{Type: "disconnect", Reason: "unauthorized", Reconnect: &reconnect}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cable, client := newCable(t, func(conn *websocket.Conn, n int) {
+				websocket.JSON.Send(conn, tt.frame)
+			})
+
+			done := make(chan error, 1)
+			go func() { done <- client.Run(context.Background(), make(chan Event, 16)) }()
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, ErrUnauthorized) {
+					t.Errorf("Run() = %v, want ErrUnauthorized", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Run() kept going after rejection")
+			}
+			if n := cable.connections.Load(); n != 1 {
+				t.Errorf("connections = %d, want 1", n)
+			}
+		})
+	}
+}
+
+func TestCancelClosesConnection(t *testing.T) {
+	hold := make(chan struct{})
+	defer close(hold)
+	cable, client := newCable(t, func(conn *websocket.Conn, n int) {
+		confirm(conn)
+		<-hold
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- client.Run(ctx, make(chan Event, 16)) }()
+	cable.expectCommand("subscribe")
+	cable.expectCommand("message") // presence
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() didn't return after cancel")
+	}
+	select {
+	case <-cable.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection left open after cancel")
+	}
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type SearchService struct {
+	client *Client
+}
+
+// SearchConversation is a conversation as returned by the search
+// endpoints, which is a smaller shape than the list endpoint's.
+type SearchConversation struct {
+	ID        int          `json:"id"`
+	AccountID int          `json:"account_id"`
+	CreatedAt int64        `json:"created_at"`
+	Message   *Message     `json:"message"`
+	Contact   *Contact     `json:"contact"`
+	Inbox     *SearchInbox `json:"inbox"`
+	Agent     *Agent       `json:"agent"`
+}
+
+type SearchInbox struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ChannelType string `json:"channel_type"`
+}
+
+type SearchConversationsResponse struct {
+	Payload struct {
+		Conversations []SearchConversation `json:"conversations"`
+	} `json:"payload"`
+}
+
+type SearchMessagesResponse struct {
+	Payload struct {
+		Messages []Message `json:"messages"`
+	} `json:"payload"`
+}
+
+// Conversations searches conversations by ID, contact and message content.
+func (s *SearchService) Conversations(query string, page int) (*SearchConversationsResponse, error) {
+	var resp SearchConversationsResponse
+	if err := s.client.Get("/search/conversations", searchParams(query, page), &resp); err != nil {
+		return nil, fmt.Errorf("failed to search conversations: %w", err)
+	}
+	return &resp, nil
+}
+
+// Messages searches message content across all conversations.
+func (s *SearchService) Messages(query string, page int) (*SearchMessagesResponse, error) {
+	var resp SearchMessagesResponse
+	if err := s.client.Get("/search/messages", searchParams(query, page), &resp); err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	return &resp, nil
+}
+
+func searchParams(query string, page int) url.Values {
+	params := url.Values{}
+	params.Set("q", query)
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	return params
+}
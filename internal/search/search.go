@@ -0,0 +1,194 @@
+// Package search combines Chatwoot's conversation and message search into
+// one result per conversation, each with snippets of its matching messages.
+// The CLI `search` command and the TUI's server-side search share it.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// SnippetWidth is the length in runes that snippets are cut to.
+const SnippetWidth = 80
+
+type Result struct {
+	ConversationID int     `json:"conversation_id"`
+	Contact        string  `json:"contact,omitempty"`
+	ContactID      int     `json:"contact_id,omitempty"`
+	Inbox          string  `json:"inbox,omitempty"`
+	Assignee       string  `json:"assignee,omitempty"`
+	LastMatchAt    int64   `json:"last_match_at"`
+	Matches        []Match `json:"matches,omitempty"`
+}
+
+// Match is one message that contains the query.
+type Match struct {
+	MessageID int    `json:"message_id"`
+	Sender    string `json:"sender,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	Snippet   string `json:"snippet"`
+	// Highlight is the rune range of the query within Snippet; both ends
+	// are 0 when it doesn't occur literally (e.g. a match on the contact).
+	Highlight [2]int `json:"highlight"`
+}
+
+// Run searches messages and conversations for query and merges the hits.
+// Conversations with matching messages come first, most recent match
+// first, followed by conversations that only matched on other fields.
+func Run(client *sdk.Client, query string, page int) ([]Result, error) {
+	msgs, err := client.Search().Messages(query, page)
+	if err != nil {
+		return nil, err
+	}
+	convs, err := client.Search().Conversations(query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	index := map[int]int{} // conversation ID -> position in results
+
+	for _, msg := range msgs.Payload.Messages {
+		i, ok := index[msg.ConversationID]
+		if !ok {
+			i = len(results)
+			index[msg.ConversationID] = i
+			results = append(results, Result{ConversationID: msg.ConversationID})
+		}
+		r := &results[i]
+
+		sender := ""
+		if msg.Sender != nil {
+			sender = msg.Sender.Name
+			// Incoming messages are sent by the contact
+			if r.Contact == "" && msg.MessageType == 0 {
+				r.Contact = sender
+				r.ContactID = msg.Sender.ID
+			}
+		}
+		snippet, start, end := Snippet(msg.Content, query, SnippetWidth)
+		r.Matches = append(r.Matches, Match{
+			MessageID: msg.ID,
+			Sender:    sender,
+			CreatedAt: msg.CreatedAt,
+			Snippet:   snippet,
+			Highlight: [2]int{start, end},
+		})
+		if msg.CreatedAt > r.LastMatchAt {
+			r.LastMatchAt = msg.CreatedAt
+		}
+	}
+
+	for _, conv := range convs.Payload.Conversations {
+		i, ok := index[conv.ID]
+		if !ok {
+			i = len(results)
+			index[conv.ID] = i
+			results = append(results, Result{ConversationID: conv.ID, LastMatchAt: conv.CreatedAt})
+		}
+		r := &results[i]
+		if conv.Contact != nil {
+			r.Contact = conv.Contact.Name
+			r.ContactID = conv.Contact.ID
+		}
+		if conv.Inbox != nil {
+			r.Inbox = conv.Inbox.Name
+		}
+		if conv.Agent != nil {
+			r.Assignee = conv.Agent.Name
+		}
+	}
+
+	return results, nil
+}
+
+// Snippet cuts content down to about width runes around the first
+// occurrence of query, or of its first word that occurs, ignoring case.
+// It returns the snippet and the rune range of the occurrence within it.
+// Whitespace is collapsed so the snippet fits on one line.
+func Snippet(content, query string, width int) (string, int, int) {
+	text := []rune(strings.Join(strings.Fields(content), " "))
+
+	start, n := find(text, []rune(query))
+	if start < 0 {
+		for _, word := range strings.Fields(query) {
+			if start, n = find(text, []rune(word)); start >= 0 {
+				break
+			}
+		}
+	}
+
+	if len(text) <= width {
+		if start < 0 {
+			return string(text), 0, 0
+		}
+		return string(text), start, start + n
+	}
+	if start < 0 {
+		return string(text[:width-1]) + "…", 0, 0
+	}
+
+	// Center the match, keeping the window inside the text. A match wider
+	// than the window starts it.
+	from := start - max(0, width-n)/2
+	if from < 0 {
+		from = 0
+	}
+	to := from + width
+	if to > len(text) {
+		to = len(text)
+		from = max(0, to-width)
+	}
+
+	var b strings.Builder
+	offset := 0
+	if from > 0 {
+		b.WriteString("…")
+		offset = 1
+		from++
+	}
+	if to < len(text) {
+		to--
+	}
+	b.WriteString(string(text[from:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	// The match may start before the window when it's wider than it
+	hs := max(offset, start-from+offset)
+	he := max(hs, min(start+n-from+offset, offset+to-from))
+	return b.String(), hs, he
+}
+
+// Highlight renders a snippet with style applied to its highlighted range.
+func Highlight(m Match, style func(string) string) string {
+	r := []rune(m.Snippet)
+	start, end := m.Highlight[0], m.Highlight[1]
+	if start < 0 || start >= end || end > len(r) {
+		return m.Snippet
+	}
+	return string(r[:start]) + style(string(r[start:end])) + string(r[end:])
+}
+
+// find returns the rune index and length of the first case-insensitive
+// occurrence of sub in text, or -1.
+func find(text, sub []rune) (int, int) {
+	if len(sub) == 0 {
+		return -1, 0
+	}
+	for i := 0; i+len(sub) <= len(text); i++ {
+		match := true
+		for j, r := range sub {
+			if unicode.ToLower(text[i+j]) != unicode.ToLower(r) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i, len(sub)
+		}
+	}
+	return -1, 0
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("q", 100)
+	filler := strings.Repeat("x", 50)
+
+	tests := []struct {
+		name    string
+		content string
+		query   string
+		want    string // highlighted text, "" for none
+	}{
+		{"short content", "Hello World", "world", "World"},
+		{"no match", "Hello World", "refund", ""},
+		{"first word fallback", "Please refund me", "refund order", "refund"},
+		{"collapses whitespace", "a\n\n  b refund", "refund", "refund"},
+		{"match at start", "refund " + strings.Repeat("y ", 100), "refund", "refund"},
+		{"match in the middle", strings.Repeat("y ", 60) + "refund" + strings.Repeat(" z", 60), "refund", "refund"},
+		{"match at the end", strings.Repeat("y ", 100) + "refund", "refund", "refund"},
+		{"long query in the middle", filler + long + filler, long, strings.Repeat("q", 78)},
+		{"long query at the end", filler + filler + long, long, strings.Repeat("q", 78)},
+		{"long query at the start", long + filler, long, strings.Repeat("q", 79)},
+		{"long query after the start", "ab " + long + filler, long, strings.Repeat("q", 78)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet, start, end := Snippet(tt.content, tt.query, SnippetWidth)
+			r := []rune(snippet)
+			if len(r) > SnippetWidth {
+				t.Fatalf("snippet is %d runes, want at most %d", len(r), SnippetWidth)
+			}
+			if start < 0 || end < start || end > len(r) {
+				t.Fatalf("highlight [%d, %d] out of range for %q", start, end, snippet)
+			}
+			if got := string(r[start:end]); got != tt.want {
+				t.Errorf("highlighted %q, want %q (snippet %q)", got, tt.want, snippet)
+			}
+			got := Highlight(Match{Snippet: snippet, Highlight: [2]int{start, end}}, func(s string) string { return "[" + s + "]" })
+			if tt.want != "" && !strings.Contains(got, "["+tt.want+"]") {
+				t.Errorf("Highlight() = %q, want %q marked", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	mark := func(s string) string { return "[" + s + "]" }
+	tests := []struct {
+		name      string
+		snippet   string
+		highlight [2]int
+		want      string
+	}{
+		{"range", "hello world", [2]int{6, 11}, "hello [world]"},
+		{"empty range", "hello world", [2]int{0, 0}, "hello world"},
+		{"negative start", "hello world", [2]int{-10, 3}, "hello world"},
+		{"end past snippet", "hello", [2]int{2, 9}, "hello"},
+		{"runes", "héllo wörld", [2]int{6, 11}, "héllo [wörld]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Highlight(Match{Snippet: tt.snippet, Highlight: tt.highlight}, mark)
+			if got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
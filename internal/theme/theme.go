@@ -0,0 +1,212 @@
+// Package theme loads the TUI's color themes. Bundled themes (light, dark,
+// high-contrast, solarized) are embedded; user themes are YAML files in
+// ~/.chatwoot/themes and can extend a bundled one.
+package theme
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed themes/*.yaml
+var bundled embed.FS
+
+// Theme is the palette of the TUI and the glamour style used for message
+// content. Empty colors keep the built-in adaptive defaults.
+type Theme struct {
+	Name    string `yaml:"name"`
+	Extends string `yaml:"extends,omitempty"` // bundled theme to start from
+	// Markdown is a glamour style name (dark, light, dracula, tokyo-night,
+	// pink, ascii) or the path to a glamour JSON style. Empty picks dark or
+	// light from the terminal background.
+	Markdown string `yaml:"markdown,omitempty"`
+	Colors   Colors `yaml:"colors"`
+}
+
+// Colors are hex (#rrggbb) or ANSI 256 (0-255) colors.
+type Colors struct {
+	Accent       string `yaml:"accent,omitempty"`
+	Muted        string `yaml:"muted,omitempty"`
+	Border       string `yaml:"border,omitempty"`
+	ActiveBorder string `yaml:"active_border,omitempty"`
+	Selected     string `yaml:"selected,omitempty"` // background of the selected row
+	Outgoing     string `yaml:"outgoing,omitempty"` // border of agent messages
+	Private      string `yaml:"private,omitempty"`  // border of private notes
+	Open         string `yaml:"open,omitempty"`
+	Resolved     string `yaml:"resolved,omitempty"`
+	Pending      string `yaml:"pending,omitempty"`
+	Snoozed      string `yaml:"snoozed,omitempty"`
+	Urgent       string `yaml:"urgent,omitempty"`
+	Error        string `yaml:"error,omitempty"`
+	Logo         string `yaml:"logo,omitempty"`
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func Dir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "themes"), nil
+}
+
+// Bundled returns the names of the embedded themes.
+func Bundled() []string {
+	entries, _ := bundled.ReadDir("themes")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the theme called name: a file path, a theme in Dir, or a
+// bundled theme, in that order. An empty name or "auto" returns nil, which
+// keeps the adaptive defaults.
+func Load(name string) (*Theme, error) {
+	if name == "" || name == "auto" {
+		return nil, nil
+	}
+
+	var data []byte
+	var source string
+	switch {
+	case strings.ContainsRune(name, os.PathSeparator) || strings.HasSuffix(name, ".yaml"):
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read theme: %w", err)
+		}
+		data, source = b, name
+	default:
+		dir, err := Dir()
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, name+".yaml")
+		b, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			data, source = b, path
+		case os.IsNotExist(err):
+			b, err := bundled.ReadFile("themes/" + name + ".yaml")
+			if err != nil {
+				return nil, fmt.Errorf("unknown theme %q (bundled: %s; or add %s)",
+					name, strings.Join(Bundled(), ", "), path)
+			}
+			data, source = b, name
+		default:
+			return nil, fmt.Errorf("failed to read theme: %w", err)
+		}
+	}
+
+	var t Theme
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %s: %w", source, err)
+	}
+	if t.Extends != "" {
+		base, err := loadBundled(t.Extends)
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %w", source, err)
+		}
+		// Decode the file again on top of its base so unset fields inherit
+		if err := yaml.Unmarshal(data, base); err != nil {
+			return nil, fmt.Errorf("failed to parse theme %s: %w", source, err)
+		}
+		own := t.Name
+		t = *base
+		t.Name = own
+	}
+	if t.Name == "" {
+		t.Name = name
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", source, err)
+	}
+	return &t, nil
+}
+
+func loadBundled(name string) (*Theme, error) {
+	data, err := bundled.ReadFile("themes/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown bundled theme %q (use %s)", name, strings.Join(Bundled(), ", "))
+	}
+	var t Theme
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Validate checks that every color parses and that Markdown names a
+// glamour style or an existing file.
+func (t *Theme) Validate() error {
+	for _, f := range t.Colors.fields() {
+		c := f[1]
+		if c == "" || hexColor.MatchString(c) {
+			continue
+		}
+		if n, err := strconv.Atoi(c); err == nil && n >= 0 && n <= 255 {
+			continue
+		}
+		return fmt.Errorf("%s: %q is not a #rrggbb or 0-255 color", f[0], c)
+	}
+	if _, ok := styles.DefaultStyles[t.Markdown]; !ok && t.Markdown != "" {
+		if _, err := os.Stat(t.Markdown); err != nil {
+			return fmt.Errorf("markdown: %q is neither a glamour style nor a readable file", t.Markdown)
+		}
+	}
+	return nil
+}
+
+// fields pairs each color with its YAML name.
+func (c Colors) fields() [][2]string {
+	return [][2]string{
+		{"accent", c.Accent}, {"muted", c.Muted}, {"border", c.Border}, {"active_border", c.ActiveBorder},
+		{"selected", c.Selected}, {"outgoing", c.Outgoing}, {"private", c.Private},
+		{"open", c.Open}, {"resolved", c.Resolved}, {"pending", c.Pending}, {"snoozed", c.Snoozed},
+		{"urgent", c.Urgent}, {"error", c.Error}, {"logo", c.Logo},
+	}
+}
+
+// MarkdownStyle returns the glamour style for message content. dark is
+// the terminal background, used when the theme doesn't name a style. t
+// may be nil.
+func (t *Theme) MarkdownStyle(dark bool) (ansi.StyleConfig, error) {
+	name := ""
+	if t != nil {
+		name = t.Markdown
+	}
+	if name == "" {
+		name = styles.LightStyle
+		if dark {
+			name = styles.DarkStyle
+		}
+	}
+	if s, ok := styles.DefaultStyles[name]; ok {
+		return *s, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("failed to read markdown style: %w", err)
+	}
+	var cfg ansi.StyleConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("failed to parse markdown style %s: %w", name, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,173 @@
+// Package timefmt renders Chatwoot epoch timestamps the same way in CLI
+// tables and the TUI, so every surface honours the user's chosen style and
+// timezone.
+package timefmt
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Style string
+
+const (
+	Relative Style = "relative"
+	ISO      Style = "iso"
+	Local    Style = "local"
+	Unix     Style = "unix"
+)
+
+// Styles lists the accepted --time-format values.
+var Styles = []Style{Relative, ISO, Local, Unix}
+
+type Formatter struct {
+	Style    Style
+	Location *time.Location
+
+	now func() time.Time
+}
+
+// New builds a Formatter from a style name and an IANA timezone name.
+// Empty values fall back to the local style in the system timezone.
+func New(style, tz string) (*Formatter, error) {
+	f := &Formatter{Style: Local, Location: time.Local, now: time.Now}
+
+	if style != "" {
+		s, err := ParseStyle(style)
+		if err != nil {
+			return nil, err
+		}
+		f.Style = s
+	}
+
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+		f.Location = loc
+	}
+
+	return f, nil
+}
+
+// Default returns the local-style formatter in the system timezone.
+func Default() *Formatter {
+	f, _ := New("", "")
+	return f
+}
+
+func ParseStyle(s string) (Style, error) {
+	for _, style := range Styles {
+		if string(style) == s {
+			return style, nil
+		}
+	}
+	return "", fmt.Errorf("invalid time format %q (want relative, iso, local or unix)", s)
+}
+
+// Time converts an epoch to a time in the formatter's location.
+func (f *Formatter) Time(epoch int64) time.Time {
+	return time.Unix(epoch, 0).In(f.Location)
+}
+
+// Format renders a full timestamp for tables and detail views.
+// Zero epochs render as an empty string.
+func (f *Formatter) Format(epoch int64) string {
+	if epoch == 0 {
+		return ""
+	}
+	t := f.Time(epoch)
+	switch f.Style {
+	case Relative:
+		return f.relative(t)
+	case ISO:
+		return t.Format(time.RFC3339)
+	case Unix:
+		return strconv.FormatInt(epoch, 10)
+	default:
+		return t.Format("2006-01-02 15:04")
+	}
+}
+
+// Short renders a compact timestamp for narrow columns such as the TUI
+// conversation list and message metadata.
+func (f *Formatter) Short(epoch int64) string {
+	if epoch == 0 {
+		return ""
+	}
+	t := f.Time(epoch)
+	now := f.now().In(f.Location)
+	sameDay := t.YearDay() == now.YearDay() && t.Year() == now.Year()
+
+	switch f.Style {
+	case Relative:
+		return f.relativeShort(t)
+	case ISO:
+		if sameDay {
+			return t.Format("15:04")
+		}
+		return t.Format("2006-01-02")
+	case Unix:
+		return strconv.FormatInt(epoch, 10)
+	default:
+		if sameDay {
+			return t.Format("3:04 PM")
+		}
+		return t.Format("Jan 2")
+	}
+}
+
+func (f *Formatter) relative(t time.Time) string {
+	d := f.now().Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var s string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		s = plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		s = plural(int(d/(24*time.Hour)), "day")
+	default:
+		return t.Format("2006-01-02")
+	}
+
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+func (f *Formatter) relativeShort(t time.Time) string {
+	d := f.now().Sub(t)
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	default:
+		return t.Format("Jan 2")
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,68 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return now.Add(d).Unix() }
+
+	tests := []struct {
+		style, tz string
+		epoch     int64
+		format    string
+		short     string
+	}{
+		{"local", "UTC", at(0), "2026-03-10 12:00", "12:00 PM"},
+		{"local", "Asia/Kolkata", at(0), "2026-03-10 17:30", "5:30 PM"},
+		{"local", "UTC", at(-48 * time.Hour), "2026-03-08 12:00", "Mar 8"},
+		{"iso", "UTC", at(0), "2026-03-10T12:00:00Z", "12:00"},
+		{"iso", "America/New_York", at(0), "2026-03-10T08:00:00-04:00", "08:00"},
+		// Today in UTC, but already tomorrow in Tokyo
+		{"iso", "UTC", at(4 * time.Hour), "2026-03-10T16:00:00Z", "16:00"},
+		{"iso", "Asia/Tokyo", at(4 * time.Hour), "2026-03-11T01:00:00+09:00", "2026-03-11"},
+		{"iso", "UTC", at(-13 * time.Hour), "2026-03-09T23:00:00Z", "2026-03-09"},
+		{"unix", "UTC", at(0), "1773144000", "1773144000"},
+		{"relative", "UTC", at(-30 * time.Second), "just now", "now"},
+		{"relative", "UTC", at(-time.Minute), "1 minute ago", "1m"},
+		{"relative", "UTC", at(-5 * time.Hour), "5 hours ago", "5h"},
+		{"relative", "UTC", at(3 * 24 * time.Hour), "in 3 days", "3d"},
+		{"relative", "UTC", at(-40 * 24 * time.Hour), "2026-01-29", "Jan 29"},
+		{"iso", "UTC", 0, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style+" "+tt.tz, func(t *testing.T) {
+			f, err := New(tt.style, tt.tz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+			if got := f.Format(tt.epoch); got != tt.format {
+				t.Errorf("Format() = %q, want %q", got, tt.format)
+			}
+			if got := f.Short(tt.epoch); got != tt.short {
+				t.Errorf("Short() = %q, want %q", got, tt.short)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		style, tz string
+		wantErr   bool
+	}{
+		{"", "", false},
+		{"relative", "Europe/Berlin", false},
+		{"fancy", "", true},
+		{"iso", "Mars/Olympus", true},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.style, tt.tz)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("New(%q, %q) error = %v, want error %v", tt.style, tt.tz, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// bulkConcurrency caps how many API calls a bulk action makes at once.
+const bulkConcurrency = 4
+
+// bulkOp is a palette action applied to each target conversation.
+type bulkOp struct {
+	label string // progress label, e.g. "Resolving"
+	run   func(client *sdk.Client, id int) error
+}
+
+// bulkState tracks a bulk action in flight.
+type bulkState struct {
+	label  string
+	total  int
+	done   int
+	failed map[int]error
+}
+
+type bulkItemMsg struct {
+	id  int
+	err error
+}
+
+// bulkOpFor returns the operation behind a palette action, or false for
+// actions that don't act on conversations.
+func bulkOpFor(action PaletteAction) (bulkOp, bool) {
+	switch action.Action {
+	case "toggle_status":
+		label := map[string]string{
+			"open": "Reopening", "resolved": "Resolving", "pending": "Marking pending", "snoozed": "Snoozing",
+		}[action.Status]
+		return bulkOp{label: label, run: func(client *sdk.Client, id int) error {
+			_, err := client.Conversations().ToggleStatus(id, action.Status, action.SnoozedUntil)
+			return err
+		}}, true
+	case "set_priority":
+		return bulkOp{label: "Setting priority", run: func(client *sdk.Client, id int) error {
+			return client.Conversations().TogglePriority(id, action.Priority)
+		}}, true
+	case "assign":
+		label := "Assigning"
+		if action.AssigneeID == 0 {
+			label = "Unassigning"
+		}
+		return bulkOp{label: label, run: func(client *sdk.Client, id int) error {
+			_, err := client.Conversations().Assign(id, action.AssigneeID, 0)
+			return err
+		}}, true
+	case "assign_team":
+		return bulkOp{label: "Assigning team", run: func(client *sdk.Client, id int) error {
+			return client.Conversations().AssignTeam(id, action.TeamID)
+		}}, true
+	case "add_label":
+		return bulkOp{label: "Labelling", run: func(client *sdk.Client, id int) error {
+			return updateLabels(client, id, action.Tag, true)
+		}}, true
+	case "remove_label":
+		return bulkOp{label: "Removing label", run: func(client *sdk.Client, id int) error {
+			return updateLabels(client, id, action.Tag, false)
+		}}, true
+	}
+	return bulkOp{}, false
+}
+
+// updateLabels adds or removes one label. The API replaces the whole set,
+// so the current labels are fetched first.
+func updateLabels(client *sdk.Client, convID int, label string, add bool) error {
+	labels, err := client.Labels(convID).List()
+	if err != nil {
+		return err
+	}
+	has := slices.Contains(labels, label)
+	switch {
+	case add && !has:
+		labels = append(labels, label)
+	case !add && has:
+		labels = slices.DeleteFunc(labels, func(l string) bool { return l == label })
+	default:
+		return nil
+	}
+	_, err = client.Labels(convID).Add(labels)
+	return err
+}
+
+// runBulk applies op to every conversation concurrently, reporting each
+// result as a bulkItemMsg.
+func runBulk(client *sdk.Client, op bulkOp, ids []int) tea.Cmd {
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			return bulkItemMsg{id: id, err: op.run(client, id)}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (b *bulkState) progress() string {
+	return fmt.Sprintf("%s %d/%d", b.label, b.done, b.total)
+}
+
+// summary lists the conversations that failed, or returns nil.
+func (b *bulkState) summary() error {
+	if len(b.failed) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(b.failed))
+	for id := range b.failed {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var parts []string
+	for _, id := range ids[:min(3, len(ids))] {
+		parts = append(parts, fmt.Sprintf("#%d %s", id, truncate(b.failed[id].Error(), 40)))
+	}
+	if len(ids) > 3 {
+		parts = append(parts, fmt.Sprintf("+%d more", len(ids)-3))
+	}
+	return fmt.Errorf("%d of %d failed (still marked): %s", len(ids), b.total, strings.Join(parts, "; "))
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+func testModel(t *testing.T, convs ...sdk.Conversation) Model {
+	t.Helper()
+	m := newModel(sdk.NewClient("http://127.0.0.1:0", "", 1), 1, "test")
+	return update(m, listMsg(m, convs...))
+}
+
+func update(m Model, msg tea.Msg) Model {
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+// listMsg is the list as the server returns it for the active tab, in a
+// fresh slice like a decoded response.
+func listMsg(m Model, convs ...sdk.Conversation) conversationsMsg {
+	return conversationsMsg{filter: m.listFilter(), conversations: slices.Clone(convs), pages: 1}
+}
+
+func TestFailedActionIsUndone(t *testing.T) {
+	priority := "urgent"
+	tests := []struct {
+		name   string
+		action PaletteAction
+		// changed reports whether the optimistic edit shows on c
+		changed func(c sdk.Conversation) bool
+	}{
+		{"assign", PaletteAction{Action: "assign", AssigneeID: 7},
+			func(c sdk.Conversation) bool { return c.Meta.Assignee != nil }},
+		{"assign team", PaletteAction{Action: "assign_team", TeamID: 3, Label: "Billing"},
+			func(c sdk.Conversation) bool { return c.Meta.Team != nil }},
+		{"add label", PaletteAction{Action: "add_label", Tag: "vip"},
+			func(c sdk.Conversation) bool { return len(c.Labels) > 0 }},
+		{"set priority", PaletteAction{Action: "set_priority", Priority: priority},
+			func(c sdk.Conversation) bool { return c.Priority != nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := []sdk.Conversation{{ID: 1, Status: "open"}, {ID: 2, Status: "open"}}
+			m := testModel(t, server...)
+
+			op, ok := bulkOpFor(tt.action)
+			if !ok {
+				t.Fatalf("no bulk op for %s", tt.action.Action)
+			}
+			m.applyOptimistic(tt.action, []int{1, 2})
+			m.startBulk(op, []int{1, 2})
+			for _, id := range []int{1, 2} {
+				if !tt.changed(*m.convList.Find(id)) {
+					t.Fatalf("conversation %d: optimistic edit not shown", id)
+				}
+			}
+
+			// Every call fails, so the server still has the original list
+			m = update(m, bulkItemMsg{id: 1, err: errors.New("forbidden")})
+			m = update(m, bulkItemMsg{id: 2, err: errors.New("forbidden")})
+			m = update(m, listMsg(m, server...))
+
+			for _, id := range []int{1, 2} {
+				if tt.changed(*m.convList.Find(id)) {
+					t.Errorf("conversation %d: optimistic edit not undone", id)
+				}
+			}
+		})
+	}
+}
+
+func TestFailedPriorityIsUndone(t *testing.T) {
+	server := []sdk.Conversation{{ID: 1, Status: "open"}}
+	m := testModel(t, server...)
+
+	m.applyOptimistic(PaletteAction{Action: "set_priority", Priority: "high"}, []int{1})
+	m = update(m, priorityMsg{conversationID: 1, err: errors.New("forbidden")})
+	m = update(m, listMsg(m, server...))
+
+	if p := m.convList.Find(1).Priority; p != nil {
+		t.Errorf("priority = %q, want none", *p)
+	}
+}
+
+func TestPartlyFailedBulkKeepsSuccesses(t *testing.T) {
+	m := testModel(t, sdk.Conversation{ID: 1, Status: "open"}, sdk.Conversation{ID: 2, Status: "open"})
+
+	m.convList.mark(1)
+	m.convList.mark(2)
+	action := PaletteAction{Action: "add_label", Tag: "vip"}
+	op, _ := bulkOpFor(action)
+	m.applyOptimistic(action, []int{1, 2})
+	m.startBulk(op, []int{1, 2})
+	m = update(m, bulkItemMsg{id: 1})
+	m = update(m, bulkItemMsg{id: 2, err: errors.New("forbidden")})
+	m = update(m, listMsg(m,
+		sdk.Conversation{ID: 1, Status: "open", Labels: []string{"vip"}},
+		sdk.Conversation{ID: 2, Status: "open"}))
+
+	if got := m.convList.Find(1).Labels; len(got) != 1 {
+		t.Errorf("conversation 1 labels = %v, want [vip]", got)
+	}
+	if got := m.convList.Find(2).Labels; len(got) != 0 {
+		t.Errorf("conversation 2 labels = %v, want none", got)
+	}
+	if !m.convList.marked[2] || m.convList.marked[1] {
+		t.Errorf("only the failed conversation should stay marked")
+	}
+}
+
+func TestBulkRefusedWhileRunning(t *testing.T) {
+	m := testModel(t,
+		sdk.Conversation{ID: 1, Status: "open"},
+		sdk.Conversation{ID: 2, Status: "open"},
+		sdk.Conversation{ID: 3, Status: "open"},
+		sdk.Conversation{ID: 4, Status: "open"})
+
+	action := PaletteAction{Action: "add_label", Tag: "vip"}
+	op, _ := bulkOpFor(action)
+	m.applyOptimistic(action, []int{1, 2})
+	m.startBulk(op, []int{1, 2})
+
+	m.palette.Open([]sdk.Conversation{*m.convList.Find(3), *m.convList.Find(4)}, paletteContext{})
+	if a := m.palette.Selected(); a == nil || a.Action != "toggle_status" {
+		t.Fatalf("first palette action = %+v, want a status change", a)
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.err == nil {
+		t.Error("no message about the running action")
+	}
+	if m.bulk.label != op.label {
+		t.Errorf("running action = %q, want %q", m.bulk.label, op.label)
+	}
+	for _, id := range []int{3, 4} {
+		if s := m.convList.Find(id).Status; s != "open" {
+			t.Errorf("conversation %d status = %q, want open", id, s)
+		}
+	}
+
+	// The first run still completes and can be followed by another
+	m = update(m, bulkItemMsg{id: 1})
+	m = update(m, bulkItemMsg{id: 2})
+	if m.bulk != nil {
+		t.Fatal("first run didn't finish")
+	}
+}
@@ -3,12 +3,13 @@ package tui
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/search"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
 	"github.com/sahilm/fuzzy"
 )
 
@@ -26,18 +27,43 @@ type ConversationList struct {
 	width, height int
 	scrollOffset  int
 
-	tabIndex    int // index into assigneeTabs
-	statusIndex int // index into statusOptions
+	views       []views.View // saved views, shown as tabs after assigneeTabs
+	tabIndex    int          // index into assigneeTabs, then views
+	statusIndex int          // index into statusOptions
 	filtering   bool
 	filterInput textinput.Model
+
+	pages       int            // pages of the active tab loaded so far
+	hasMore     bool           // the active tab has further pages
+	loadingMore bool           // a further page is being fetched
+	counts      map[string]int // totals per assignee tab, from the last fetch
+	changed     map[int]bool   // conversations updated since they were last opened
+
+	marked map[int]bool // multi-selection for bulk palette actions
+	anchor int          // last conversation marked with Space, where a V range starts
+
+	// Server-side search results, listed when the filter matches nothing
+	// locally. They belong to searchQuery; remote is nil until they arrive.
+	searchQuery string
+	searching   bool
+	remote      []sdk.Conversation
+	snippets    map[int]search.Match // best matching message per conversation
 }
 
+// loadMoreThreshold is how close to the end of the list the cursor gets
+// before the next page is fetched.
+const loadMoreThreshold = 5
+
+// minSearchLen is the shortest filter that falls back to server search.
+const minSearchLen = 3
+
 func NewConversationList() ConversationList {
 	ti := textinput.New()
 	ti.Placeholder = "Search conversations..."
 	ti.CharLimit = 100
 	return ConversationList{
 		filterInput: ti,
+		pages:       1,
 	}
 }
 
@@ -46,17 +72,361 @@ func (c *ConversationList) StatusFilter() string {
 }
 
 func (c *ConversationList) AssigneeType() string {
+	if v := c.ActiveView(); v != nil {
+		return v.AssigneeOrDefault()
+	}
 	return assigneeTabs[c.tabIndex]
 }
 
+// SetViews adds a tab for each saved view.
+func (c *ConversationList) SetViews(vs []views.View) {
+	c.views = vs
+}
+
+// ActiveView returns the saved view of the active tab, or nil on the
+// built-in assignee tabs.
+func (c *ConversationList) ActiveView() *views.View {
+	if c.tabIndex < len(assigneeTabs) {
+		return nil
+	}
+	return &c.views[c.tabIndex-len(assigneeTabs)]
+}
+
+// FilterName identifies the active tab: the assignee type for built-in
+// tabs and "view:<name>" for saved views.
+func (c *ConversationList) FilterName() string {
+	if v := c.ActiveView(); v != nil {
+		return "view:" + v.Name
+	}
+	return c.AssigneeType()
+}
+
 func (c *ConversationList) SetSize(w, h int) {
 	c.width = w
 	c.height = h
 }
 
+// SetConversations replaces the list with a refresh, keeping the selected
+// conversation selected by ID. If it has left the list, the cursor keeps
+// its position and so lands on the conversation that followed it.
 func (c *ConversationList) SetConversations(convs []sdk.Conversation) {
+	selectedID := c.selectedID()
 	c.conversations = convs
+	for id := range c.marked {
+		if c.Find(id) == nil {
+			delete(c.marked, id)
+		}
+	}
 	c.applyFilter()
+	c.reselect(selectedID)
+}
+
+// ToggleMark marks or unmarks the selected conversation.
+func (c *ConversationList) ToggleMark() {
+	sel := c.Selected()
+	if sel == nil {
+		return
+	}
+	if c.marked[sel.ID] {
+		delete(c.marked, sel.ID)
+	} else {
+		c.mark(sel.ID)
+	}
+	c.anchor = sel.ID
+}
+
+// MarkRange marks every conversation between the last one marked with
+// Space and the cursor.
+func (c *ConversationList) MarkRange() {
+	from := c.cursor
+	for i, conv := range c.filtered {
+		if conv.ID == c.anchor {
+			from = i
+		}
+	}
+	lo, hi := min(from, c.cursor), max(from, c.cursor)
+	for i := lo; i <= hi && i < len(c.filtered); i++ {
+		c.mark(c.filtered[i].ID)
+	}
+}
+
+// ToggleMarkAll marks every filtered conversation, or clears the marks if
+// they all are already.
+func (c *ConversationList) ToggleMarkAll() {
+	all := len(c.filtered) > 0
+	for _, conv := range c.filtered {
+		all = all && c.marked[conv.ID]
+	}
+	if all {
+		c.ClearMarks()
+		return
+	}
+	for _, conv := range c.filtered {
+		c.mark(conv.ID)
+	}
+}
+
+func (c *ConversationList) mark(id int) {
+	if c.marked == nil {
+		c.marked = map[int]bool{}
+	}
+	c.marked[id] = true
+}
+
+// Unmark removes the mark from one conversation.
+func (c *ConversationList) Unmark(id int) {
+	delete(c.marked, id)
+}
+
+func (c *ConversationList) ClearMarks() {
+	c.marked = nil
+	c.anchor = 0
+}
+
+func (c *ConversationList) MarkedCount() int {
+	return len(c.marked)
+}
+
+// Marked returns the marked conversations in list order.
+func (c *ConversationList) Marked() []sdk.Conversation {
+	var convs []sdk.Conversation
+	for _, conv := range c.conversations {
+		if c.marked[conv.ID] {
+			convs = append(convs, conv)
+		}
+	}
+	return convs
+}
+
+// MarkChanged highlights conversations until they are next opened.
+func (c *ConversationList) MarkChanged(ids ...int) {
+	if c.changed == nil {
+		c.changed = map[int]bool{}
+	}
+	for _, id := range ids {
+		c.changed[id] = true
+	}
+}
+
+// MarkSeen removes the highlight from a conversation.
+func (c *ConversationList) MarkSeen(id int) {
+	delete(c.changed, id)
+}
+
+// Pages returns how many pages of the active tab are loaded, which a
+// refresh reloads.
+func (c *ConversationList) Pages() int {
+	return c.pages
+}
+
+// SetPages records the pages a refresh covered and whether more exist.
+func (c *ConversationList) SetPages(pages int, hasMore bool) {
+	c.pages = max(1, pages)
+	c.hasMore = hasMore
+}
+
+// SetCounts updates the per-tab totals shown in the tab labels.
+func (c *ConversationList) SetCounts(counts map[string]int) {
+	c.counts = counts
+}
+
+// ShouldLoadMore reports whether the cursor is close enough to the end of
+// the list to fetch the next page. Filtered lists don't page.
+func (c *ConversationList) ShouldLoadMore() bool {
+	return c.hasMore && !c.loadingMore && c.filterInput.Value() == "" &&
+		c.cursor >= len(c.filtered)-loadMoreThreshold
+}
+
+func (c *ConversationList) SetLoadingMore() {
+	c.loadingMore = true
+}
+
+// AppendPage adds a further page below the loaded conversations, skipping
+// any that moved up into an earlier page since it was loaded.
+func (c *ConversationList) AppendPage(convs []sdk.Conversation, pages int, hasMore bool) {
+	c.loadingMore = false
+	c.hasMore = hasMore
+	c.pages = max(c.pages, pages)
+	selectedID := c.selectedID()
+	for _, conv := range convs {
+		if c.Find(conv.ID) == nil {
+			c.conversations = append(c.conversations, conv)
+		}
+	}
+	c.applyFilter()
+	c.reselect(selectedID)
+}
+
+// LoadMoreFailed allows the next page to be requested again.
+func (c *ConversationList) LoadMoreFailed() {
+	c.loadingMore = false
+}
+
+// resetPages forgets the pages of the previous tab or status.
+func (c *ConversationList) resetPages() {
+	c.pages = 1
+	c.hasMore = false
+	c.loadingMore = false
+}
+
+// Upsert applies a pushed update: the conversation is replaced in place if
+// listed, added to the top if it now belongs in the active tab and status,
+// or dropped if it no longer does. The selection follows its conversation.
+func (c *ConversationList) Upsert(conv sdk.Conversation, userID int) {
+	selectedID := c.selectedID()
+	belongs := c.matches(conv, userID)
+
+	for i := range c.conversations {
+		if c.conversations[i].ID != conv.ID {
+			continue
+		}
+		if belongs {
+			c.conversations[i] = conv
+		} else {
+			c.conversations = append(c.conversations[:i:i], c.conversations[i+1:]...)
+		}
+		c.applyFilter()
+		c.reselect(selectedID)
+		return
+	}
+
+	if belongs {
+		c.conversations = append([]sdk.Conversation{conv}, c.conversations...)
+		c.applyFilter()
+		c.reselect(selectedID)
+	}
+}
+
+// Touch records new activity on a listed conversation and moves it to the
+// top, matching the list's last-activity ordering.
+func (c *ConversationList) Touch(convID int, at int64, incoming bool) {
+	for i := range c.conversations {
+		if c.conversations[i].ID != convID {
+			continue
+		}
+		selectedID := c.selectedID()
+		conv := c.conversations[i]
+		conv.LastActivityAt = at
+		if incoming {
+			conv.UnreadCount++
+		}
+		rest := append(c.conversations[:i:i], c.conversations[i+1:]...)
+		c.conversations = append([]sdk.Conversation{conv}, rest...)
+		c.applyFilter()
+		c.reselect(selectedID)
+		return
+	}
+}
+
+// Find returns the listed conversation with the given ID, or nil.
+func (c *ConversationList) Find(id int) *sdk.Conversation {
+	for i := range c.conversations {
+		if c.conversations[i].ID == id {
+			return &c.conversations[i]
+		}
+	}
+	return nil
+}
+
+// Focus moves the cursor to the conversation with the given ID and
+// reports whether it is listed.
+func (c *ConversationList) Focus(id int) bool {
+	for _, conv := range c.filtered {
+		if conv.ID == id {
+			c.reselect(id)
+			return true
+		}
+	}
+	return false
+}
+
+// Show lists conv at the top and selects it, even if it doesn't belong to
+// the active tab. The next refresh that changes the list drops it again.
+func (c *ConversationList) Show(conv sdk.Conversation) {
+	if c.Focus(conv.ID) {
+		return
+	}
+	c.conversations = append([]sdk.Conversation{conv}, c.conversations...)
+	c.applyFilter()
+	c.reselect(conv.ID)
+}
+
+// SetPriority updates a listed conversation's priority in place, ahead of
+// the API call. "none" clears it.
+func (c *ConversationList) SetPriority(id int, priority string) {
+	var p *string
+	if priority != "none" {
+		p = &priority
+	}
+	c.Modify(id, func(conv *sdk.Conversation) { conv.Priority = p })
+}
+
+// Modify applies fn to a listed conversation, e.g. for an optimistic
+// update ahead of the API call.
+func (c *ConversationList) Modify(id int, fn func(*sdk.Conversation)) {
+	for i := range c.conversations {
+		if c.conversations[i].ID == id {
+			fn(&c.conversations[i])
+		}
+	}
+	// filtered is a separate copy while a filter is active
+	if len(c.filtered) > 0 && len(c.conversations) > 0 && &c.filtered[0] == &c.conversations[0] {
+		return
+	}
+	for i := range c.filtered {
+		if c.filtered[i].ID == id {
+			fn(&c.filtered[i])
+		}
+	}
+}
+
+// matches reports whether conv belongs under the active status and assignee tab.
+func (c *ConversationList) matches(conv sdk.Conversation, userID int) bool {
+	if v := c.ActiveView(); v != nil {
+		// Query views can't be evaluated locally, so only keep what the
+		// last fetch listed
+		if v.Query != "" {
+			return c.Find(conv.ID) != nil
+		}
+		return v.Matches(conv, userID)
+	}
+	if conv.Status != c.StatusFilter() {
+		return false
+	}
+	switch c.AssigneeType() {
+	case "me":
+		return conv.Meta.Assignee != nil && conv.Meta.Assignee.ID == userID
+	case "unassigned":
+		return conv.Meta.Assignee == nil
+	}
+	return true
+}
+
+func (c *ConversationList) selectedID() int {
+	if sel := c.Selected(); sel != nil {
+		return sel.ID
+	}
+	return 0
+}
+
+// reselect moves the cursor back onto the conversation with the given ID
+// after the list has been reordered. The cursor stays put if it's gone.
+func (c *ConversationList) reselect(id int) {
+	for i, conv := range c.filtered {
+		if conv.ID == id {
+			c.cursor = i
+			break
+		}
+	}
+	if c.cursor >= len(c.filtered) {
+		c.cursor = max(0, len(c.filtered)-1)
+	}
+	visible := c.visibleRows()
+	if c.cursor < c.scrollOffset {
+		c.scrollOffset = c.cursor
+	} else if c.cursor >= c.scrollOffset+visible {
+		c.scrollOffset = c.cursor - visible + 1
+	}
 }
 
 func (c *ConversationList) Selected() *sdk.Conversation {
@@ -75,6 +445,13 @@ func (c *ConversationList) applyFilter() {
 		c.filtered = c.conversations
 		return
 	}
+	if c.IsRemote() {
+		c.filtered = c.remote
+		if c.cursor >= len(c.filtered) {
+			c.cursor = 0
+		}
+		return
+	}
 
 	strs := make([]string, len(c.conversations))
 	for i, conv := range c.conversations {
@@ -100,18 +477,75 @@ func (c *ConversationList) applyFilter() {
 	}
 }
 
+// PendingSearch returns the filter text when it should be searched on the
+// server: it is long enough, matches nothing loaded, and hasn't been
+// searched yet.
+func (c *ConversationList) PendingSearch() (string, bool) {
+	query := strings.TrimSpace(c.filterInput.Value())
+	if len([]rune(query)) < minSearchLen || query == c.searchQuery || len(c.filtered) > 0 {
+		return "", false
+	}
+	return query, true
+}
+
+// SetSearching marks a server search for query as in flight.
+func (c *ConversationList) SetSearching(query string) {
+	c.searchQuery = query
+	c.searching = true
+	c.remote = nil
+	c.snippets = nil
+}
+
+// SetSearchResults lists the results of the server search for query,
+// unless the filter has moved on since it was started.
+func (c *ConversationList) SetSearchResults(query string, results []search.Result) {
+	if query != c.searchQuery {
+		return
+	}
+	c.searching = false
+	c.remote = make([]sdk.Conversation, 0, len(results))
+	c.snippets = map[int]search.Match{}
+	for _, r := range results {
+		conv := sdk.Conversation{ID: r.ConversationID, LastActivityAt: r.LastMatchAt}
+		if r.Contact != "" {
+			conv.Meta.Sender = &sdk.Contact{ID: r.ContactID, Name: r.Contact}
+		}
+		if len(r.Matches) > 0 {
+			c.snippets[r.ConversationID] = r.Matches[0]
+		}
+		c.remote = append(c.remote, conv)
+	}
+	c.applyFilter()
+}
+
+// IsRemote reports whether the list shows server search results, which
+// are partial conversations that have to be fetched before use.
+func (c *ConversationList) IsRemote() bool {
+	return c.remote != nil && c.filterInput.Value() != "" &&
+		strings.TrimSpace(c.filterInput.Value()) == c.searchQuery
+}
+
+// ClearFilter ends filtering and drops the filter text and search results.
+func (c *ConversationList) ClearFilter() {
+	c.filtering = false
+	c.filterInput.Blur()
+	c.filterInput.SetValue("")
+	c.searchQuery = ""
+	c.searching = false
+	c.remote = nil
+	c.snippets = nil
+	c.applyFilter()
+}
+
 func (c *ConversationList) Update(msg tea.Msg) tea.Cmd {
 	if c.filtering {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			switch msg.String() {
-			case "esc":
-				c.filtering = false
-				c.filterInput.Blur()
-				c.filterInput.SetValue("")
-				c.applyFilter()
+			switch {
+			case matchKey(msg, keys.Cancel):
+				c.ClearFilter()
 				return nil
-			case "enter":
+			case matchKey(msg, keys.Confirm):
 				c.filtering = false
 				c.filterInput.Blur()
 				return nil
@@ -145,13 +579,53 @@ func (c *ConversationList) MoveDown() {
 }
 
 func (c *ConversationList) CycleTab() {
-	c.tabIndex = (c.tabIndex + 1) % len(assigneeTabs)
+	c.SetTab((c.tabIndex + 1) % (len(assigneeTabs) + len(c.views)))
+}
+
+// SetTab switches to the tab at index i, counting saved views after the
+// assignee tabs.
+func (c *ConversationList) SetTab(i int) {
+	c.tabIndex = i
+	c.resetPages()
 	c.cursor = 0
 	c.scrollOffset = 0
 }
 
+// TabAt returns the tab under column x of the tab line, or -1. status is
+// true when x is on the status indicator that follows the tabs.
+func (c *ConversationList) TabAt(x int) (tab int, status bool) {
+	edge := 0
+	for i, label := range c.tabTitles() {
+		edge += lipgloss.Width(statusTabInactive.Render(label))
+		if x < edge {
+			return i, false
+		}
+	}
+	return -1, x >= edge
+}
+
+// Click selects the conversation on the given row of the list (0 is the
+// first row below the filter line). It reports whether there was one.
+func (c *ConversationList) Click(row int) bool {
+	i := c.scrollOffset + row
+	if row < 0 || i >= len(c.filtered) {
+		return false
+	}
+	c.cursor = i
+	return true
+}
+
+// listHeaderRows is the number of lines above the first conversation row.
+const listHeaderRows = 2
+
+// CycleStatus moves to the next status. Saved views carry their own
+// status, so it does nothing on their tabs.
 func (c *ConversationList) CycleStatus() {
+	if c.ActiveView() != nil {
+		return
+	}
 	c.statusIndex = (c.statusIndex + 1) % len(statusOptions)
+	c.resetPages()
 	c.cursor = 0
 	c.scrollOffset = 0
 }
@@ -167,19 +641,34 @@ func (c *ConversationList) IsFiltering() bool {
 
 func (c *ConversationList) visibleRows() int {
 	// 2 header lines (tabs + filter), each row = 1 line
-	available := c.height - 2
+	available := c.height - listHeaderRows
 	if available < 1 {
 		return 1
 	}
 	return available
 }
 
+// tabTitles labels the assignee tabs with their counts (Mine 12 |
+// Unassigned 40 | All 130), followed by the saved views.
+func (c *ConversationList) tabTitles() []string {
+	labels := make([]string, len(tabLabels), len(tabLabels)+len(c.views))
+	for i, label := range tabLabels {
+		labels[i] = label
+		if n, ok := c.counts[assigneeTabs[i]]; ok {
+			labels[i] = fmt.Sprintf("%s %d", label, n)
+		}
+	}
+	for _, v := range c.views {
+		labels = append(labels, v.Name)
+	}
+	return labels
+}
+
 func (c *ConversationList) View() string {
 	var b strings.Builder
 
-	// Assignee tabs: Mine | Unassigned | All
 	var tabs []string
-	for i, label := range tabLabels {
+	for i, label := range c.tabTitles() {
 		if i == c.tabIndex {
 			tabs = append(tabs, statusTabActive.Render(label))
 		} else {
@@ -190,12 +679,19 @@ func (c *ConversationList) View() string {
 	// Status indicator: just dot + label, no "Status:" prefix
 	statusLabel := " " + statusDot(statusOptions[c.statusIndex]) + " " +
 		lipgloss.NewStyle().Bold(true).Render(statusLabels[c.statusIndex])
+	if v := c.ActiveView(); v != nil {
+		status := v.StatusOrDefault()
+		statusLabel = " " + statusDot(status) + " " +
+			lipgloss.NewStyle().Bold(true).Render(strings.ToUpper(status[:1])+status[1:])
+	}
 	b.WriteString(tabLine + statusLabel)
 	b.WriteString("\n")
 
 	// Filter line
 	if c.filtering {
 		b.WriteString(filterStyle.Render("/ ") + c.filterInput.View())
+	} else if c.IsRemote() {
+		b.WriteString(filterStyle.Render("server: " + c.filterInput.Value()))
 	} else if c.filterInput.Value() != "" {
 		b.WriteString(filterStyle.Render("filter: " + c.filterInput.Value()))
 	} else {
@@ -211,7 +707,13 @@ func (c *ConversationList) View() string {
 	}
 
 	if len(c.filtered) == 0 {
-		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render("  No conversations"))
+		empty := "  No conversations"
+		if c.searching {
+			empty = "  Searching server…"
+		} else if c.IsRemote() {
+			empty = "  No matches on server"
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(empty))
 		b.WriteString("\n")
 	}
 
@@ -236,25 +738,39 @@ func (c *ConversationList) renderRow(conv sdk.Conversation, selected bool) strin
 		name = "Unknown"
 	}
 
-	ts := formatTime(conv.LastActivityAt)
+	ts := timeFormat.Short(conv.LastActivityAt)
 	idStr := fmt.Sprintf("#%d", conv.ID)
 	dot := statusDot(conv.Status)
+	badge := priorityIcon("none")
+	if conv.Priority != nil {
+		badge = priorityIcon(*conv.Priority)
+	}
 
-	// Cursor(2) + dot(1) + space(1) + id + space(1) + name + space(1) + ts
+	// Cursor(2) + dot(1) + badge(1) + space(1) + id + space(1) + name + space(1) + ts
 	prefix := "  "
 	if selected {
 		prefix = "> "
 	}
+	if c.marked[conv.ID] {
+		prefix = prefix[:1] + convMarkedStyle.Render("✓")
+	}
 
-	fixedW := 2 + 1 + 1 + len(idStr) + 1 + 1 + len(ts) // prefix + dot + spaces + id + ts
+	fixedW := 2 + 1 + 1 + 1 + len(idStr) + 1 + 1 + len(ts) // prefix + dot + badge + spaces + id + ts
 	nameW := c.width - fixedW
 	if nameW < 3 {
 		nameW = 3
 	}
 	truncName := truncate(name, nameW)
 	padded := truncName + strings.Repeat(" ", nameW-len([]rune(truncName)))
+	if m, ok := c.snippets[conv.ID]; ok && c.IsRemote() {
+		padded = snippetColumn(name, m, nameW)
+	}
 
-	line := prefix + dot + " " + idStr + " " + padded + " " + ts
+	if c.changed[conv.ID] && !selected {
+		padded = convChangedStyle.Render(padded)
+	}
+
+	line := prefix + dot + badge + " " + idStr + " " + padded + " " + ts
 
 	if selected {
 		line = convSelectedStyle.Render(line)
@@ -262,16 +778,27 @@ func (c *ConversationList) renderRow(conv sdk.Conversation, selected bool) strin
 	return line
 }
 
-func formatTime(epoch int64) string {
-	if epoch == 0 {
-		return ""
+// snippetColumn fills the name column of a search result with the contact
+// name and the matching text, the match highlighted if it fits.
+func snippetColumn(name string, m search.Match, width int) string {
+	prefix := []rune(name + ": ")
+	if len(prefix) >= width {
+		t := truncate(name, width)
+		return t + strings.Repeat(" ", width-len([]rune(t)))
+	}
+	snippet := []rune(m.Snippet)
+	room := width - len(prefix)
+	if len(snippet) > room {
+		snippet = snippet[:room]
 	}
-	t := time.Unix(epoch, 0)
-	now := time.Now()
-	if t.YearDay() == now.YearDay() && t.Year() == now.Year() {
-		return t.Format("3:04 PM")
+	start, end := m.Highlight[0], min(m.Highlight[1], len(snippet))
+	muted := lipgloss.NewStyle().Foreground(colorMuted)
+	text := muted.Render(string(snippet))
+	if start < end {
+		text = muted.Render(string(snippet[:start])) + filterStyle.Bold(true).Render(string(snippet[start:end])) +
+			muted.Render(string(snippet[end:]))
 	}
-	return t.Format("Jan 2")
+	return string(prefix) + text + strings.Repeat(" ", room-len(snippet))
 }
 
 func truncate(s string, maxLen int) string {
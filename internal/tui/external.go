@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// External programs: the reply editor hands its draft to $EDITOR and the
+// message pane pipes the transcript to $PAGER. Both suspend the TUI while
+// they run.
+
+type editorMsg struct {
+	content string
+	err     error
+}
+
+type transcriptMsg struct {
+	conversationID int
+	messages       []sdk.Message
+	err            error
+}
+
+type pagerMsg struct {
+	err error
+}
+
+// shellCommand runs command through the system shell with args appended,
+// so $EDITOR and $PAGER may carry their own flags ("code --wait").
+func shellCommand(command string, args ...string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", append([]string{"/C", command}, args...)...)
+	}
+	return exec.Command("sh", append([]string{"-c", command + ` "$@"`, "sh"}, args...)...)
+}
+
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+func pagerCommand() string {
+	if v := strings.TrimSpace(os.Getenv("PAGER")); v != "" {
+		return v
+	}
+	if runtime.GOOS == "windows" {
+		return "more"
+	}
+	return "less"
+}
+
+// editDraft opens draft in the user's editor and returns the saved text.
+func editDraft(draft string) tea.Cmd {
+	f, err := os.CreateTemp("", "chatwoot-reply-*.md")
+	if err != nil {
+		return func() tea.Msg { return editorMsg{err: fmt.Errorf("failed to create draft file: %w", err)} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(draft)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return editorMsg{err: fmt.Errorf("failed to write draft file: %w", err)} }
+	}
+
+	editor := editorCommand()
+	return tea.ExecProcess(shellCommand(editor, path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorMsg{err: fmt.Errorf("editor %q failed: %w", editor, err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return editorMsg{err: fmt.Errorf("failed to read draft file: %w", err)}
+		}
+		// Editors end the file with a newline the reply shouldn't keep
+		return editorMsg{content: strings.TrimRight(string(data), "\r\n")}
+	})
+}
+
+// fetchTranscript loads the whole history of a conversation, not just the
+// pages the message pane has loaded.
+func fetchTranscript(client *sdk.Client, convID int) tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := client.Messages(convID).Latest(0)
+		return transcriptMsg{conversationID: convID, messages: msgs, err: err}
+	}
+}
+
+// openPager shows text in the user's pager.
+func openPager(text string) tea.Cmd {
+	pager := pagerCommand()
+	cmd := shellCommand(pager)
+	cmd.Stdin = strings.NewReader(text)
+	// Like git: keep colors, and exit straight away if it fits on one screen
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return pagerMsg{fmt.Errorf("pager %q failed: %w", pager, err)}
+		}
+		return pagerMsg{}
+	})
+}
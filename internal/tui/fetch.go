@@ -1,16 +1,25 @@
 package tui
 
 import (
+	"context"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk/realtime"
+	"github.com/chatwoot/chatwoot-cli/internal/search"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
 )
 
 // Messages returned by async fetches
 
 type conversationsMsg struct {
+	filter        string // "assignee/status" or "view:<name>" the list was fetched for
 	conversations []sdk.Conversation
+	pages         int            // pages the conversations cover, starting from page 1 unless more is set
+	more          bool           // a further page to append, not a refresh
+	hasMore       bool           // later pages exist
+	counts        map[string]int // totals per assignee tab; nil for saved views
 	err           error
 }
 
@@ -18,6 +27,7 @@ type messagesMsg struct {
 	conversationID int
 	messages       []sdk.Message
 	prepend        bool // true when loading older messages via pagination
+	refresh        bool // true when reloading the open conversation in place
 	err            error
 }
 
@@ -27,8 +37,21 @@ type contactMsg struct {
 }
 
 type profileMsg struct {
-	name string
-	err  error
+	id           int
+	name         string
+	availability string
+	pubsubToken  string
+	err          error
+}
+
+type priorityMsg struct {
+	conversationID int
+	err            error
+}
+
+type availabilityMsg struct {
+	availability string
+	err          error
 }
 
 type replyMsg struct {
@@ -44,16 +67,59 @@ type toggleStatusMsg struct {
 
 type tickMsg time.Time
 
+// searchDebounceMsg fires once typing in the filter has paused.
+type searchDebounceMsg struct {
+	query string
+}
+
+type searchMsg struct {
+	query   string
+	results []search.Result
+	err     error
+}
+
+type notificationsMsg struct {
+	notifications []sdk.Notification
+	unread        int
+	err           error
+}
+
+type unreadCountMsg struct {
+	count int
+	err   error
+}
+
+type notificationReadMsg struct {
+	err error
+}
+
+// openConversationMsg carries a conversation fetched by ID, e.g. to jump to
+// it from a notification when it isn't in the current list.
+type openConversationMsg struct {
+	conversation *sdk.Conversation
+	err          error
+}
+
 type agentsMsg struct {
 	agents []sdk.AgentFull
 	err    error
 }
 
+type labelsMsg struct {
+	labels []sdk.AccountLabel
+	err    error
+}
+
 type teamsMsg struct {
 	teams []sdk.TeamFull
 	err   error
 }
 
+// realtimeMsg wraps an event pushed over the ActionCable connection.
+type realtimeMsg struct {
+	event realtime.Event
+}
+
 type errMsg struct{ err error }
 
 func (e errMsg) Error() string { return e.err.Error() }
@@ -66,26 +132,70 @@ func fetchProfile(client *sdk.Client) tea.Cmd {
 		if err != nil {
 			return profileMsg{err: err}
 		}
-		return profileMsg{name: profile.Name}
+		return profileMsg{
+			id:           profile.ID,
+			name:         profile.Name,
+			availability: profile.AvailabilityStatus,
+			pubsubToken:  profile.PubsubToken,
+		}
 	}
 }
 
-func fetchConversations(client *sdk.Client, status, assigneeType string, page int) tea.Cmd {
+// fetchConversations loads pages from through to of a built-in tab. A
+// refresh (from 1) reloads every page the list has, so scrolled-in
+// conversations stay put; from > 1 fetches a further page to append.
+func fetchConversations(client *sdk.Client, status, assigneeType string, from, to int) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := client.Conversations().List(sdk.ListOptions{
-			Status:       status,
-			AssigneeType: assigneeType,
-			Page:         page,
-			SortBy:       "last_activity_at_desc",
-		})
-		if err != nil {
-			return conversationsMsg{err: err}
+		msg := conversationsMsg{filter: assigneeType + "/" + status, pages: to, more: from > 1}
+		for page := from; page <= to; page++ {
+			resp, err := client.Conversations().List(sdk.ListOptions{
+				Status:       status,
+				AssigneeType: assigneeType,
+				Page:         page,
+				SortBy:       "last_activity_at_desc",
+			})
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			meta := resp.Data.Meta
+			msg.counts = map[string]int{"me": meta.MineCount, "unassigned": meta.UnassignedCount, "all": meta.AllCount}
+			msg.conversations = append(msg.conversations, resp.Data.Payload...)
+			msg.hasMore = len(resp.Data.Payload) > 0 && page*conversationsPageSize < msg.counts[assigneeType]
+			if !msg.hasMore {
+				msg.pages = page
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// fetchView loads pages from through to of a saved view, like
+// fetchConversations. userID resolves "me" in views that use the filter
+// API. Without totals, a view has more pages until the server returns an
+// empty one; a page the view's priority filter emptied doesn't count.
+func fetchView(client *sdk.Client, v views.View, userID, from, to int) tea.Cmd {
+	return func() tea.Msg {
+		msg := conversationsMsg{filter: "view:" + v.Name, pages: to, more: from > 1}
+		for page := from; page <= to; page++ {
+			convs, more, err := views.Fetch(client, v, page, userID)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			if !more {
+				msg.pages = max(1, page-1)
+				msg.hasMore = false
+				break
+			}
+			msg.conversations = append(msg.conversations, convs...)
+			msg.hasMore = true
 		}
-		return conversationsMsg{conversations: resp.Data.Payload}
+		return msg
 	}
 }
 
-// TODO: paginate messages using beforeID to load older messages on scroll
 func fetchMessages(client *sdk.Client, convID int) tea.Cmd {
 	return func() tea.Msg {
 		resp, err := client.Messages(convID).List(0)
@@ -96,6 +206,18 @@ func fetchMessages(client *sdk.Client, convID int) tea.Cmd {
 	}
 }
 
+// refreshMessages reloads the latest messages of the open conversation,
+// to be merged into the pane without moving its scroll position.
+func refreshMessages(client *sdk.Client, convID int) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.Messages(convID).List(0)
+		if err != nil {
+			return messagesMsg{conversationID: convID, err: err, refresh: true}
+		}
+		return messagesMsg{conversationID: convID, messages: resp.Payload, refresh: true}
+	}
+}
+
 func fetchMoreMessages(client *sdk.Client, convID, beforeID int) tea.Cmd {
 	return func() tea.Msg {
 		resp, err := client.Messages(convID).List(beforeID)
@@ -126,6 +248,23 @@ func toggleStatus(client *sdk.Client, convID int, status string, snoozedUntil *i
 	}
 }
 
+func setPriority(client *sdk.Client, convID int, priority string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Conversations().TogglePriority(convID, priority)
+		return priorityMsg{conversationID: convID, err: err}
+	}
+}
+
+func setAvailability(client *sdk.Client, availability string) tea.Cmd {
+	return func() tea.Msg {
+		profile, err := client.Profile().SetAvailability(availability)
+		if err != nil {
+			return availabilityMsg{err: err}
+		}
+		return availabilityMsg{availability: profile.AvailabilityStatus}
+	}
+}
+
 func sendMessage(client *sdk.Client, convID int, content string, private bool) tea.Cmd {
 	return func() tea.Msg {
 		_, err := client.Messages(convID).Create(content, private)
@@ -153,6 +292,97 @@ func fetchTeams(client *sdk.Client) tea.Cmd {
 	}
 }
 
+func fetchLabels(client *sdk.Client) tea.Cmd {
+	return func() tea.Msg {
+		labels, err := client.AccountLabels().List()
+		if err != nil {
+			return labelsMsg{err: err}
+		}
+		return labelsMsg{labels: labels}
+	}
+}
+
+func fetchNotifications(client *sdk.Client) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.Notifications().List(1)
+		if err != nil {
+			return notificationsMsg{err: err}
+		}
+		return notificationsMsg{notifications: resp.Data.Payload, unread: resp.Data.Meta.UnreadCount}
+	}
+}
+
+func fetchUnreadCount(client *sdk.Client) tea.Cmd {
+	return func() tea.Msg {
+		count, err := client.Notifications().UnreadCount()
+		return unreadCountMsg{count: count, err: err}
+	}
+}
+
+func markNotificationRead(client *sdk.Client, id int) tea.Cmd {
+	return func() tea.Msg {
+		return notificationReadMsg{err: client.Notifications().MarkRead(id)}
+	}
+}
+
+func markAllNotificationsRead(client *sdk.Client) tea.Cmd {
+	return func() tea.Msg {
+		return notificationReadMsg{err: client.Notifications().MarkAllRead()}
+	}
+}
+
+// conversationsPageSize is how many conversations the list endpoint returns
+// per page.
+const conversationsPageSize = 25
+
+// searchDebounce is how long the filter has to stay unchanged before it is
+// searched on the server.
+const searchDebounce = 400 * time.Millisecond
+
+func debounceSearch(query string) tea.Cmd {
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{query: query}
+	})
+}
+
+func searchServer(client *sdk.Client, query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := search.Run(client, query, 1)
+		return searchMsg{query: query, results: results, err: err}
+	}
+}
+
+func fetchConversation(client *sdk.Client, convID int) tea.Cmd {
+	return func() tea.Msg {
+		conv, err := client.Conversations().Get(convID)
+		return openConversationMsg{conversation: conv, err: err}
+	}
+}
+
+// startRealtime connects to the ActionCable endpoint in the background until
+// ctx is cancelled and returns the channel events are delivered on. The
+// channel is closed if the server rejects the connection, which ends the
+// waitForEvent loop.
+func startRealtime(ctx context.Context, client *sdk.Client, pubsubToken string, userID int) chan realtime.Event {
+	events := make(chan realtime.Event, 64)
+	rt := realtime.New(client.BaseURL, pubsubToken, client.AccountID, userID)
+	go func() {
+		rt.Run(ctx, events)
+		close(events)
+	}()
+	return events
+}
+
+func waitForEvent(events <-chan realtime.Event) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return realtimeMsg{event: ev}
+	}
+}
+
 func autoRefreshTick() tea.Cmd {
 	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
 		return tickMsg(t)
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HelpOverlay is a full-screen, scrollable reference of the active keymap,
+// grouped by where each binding works.
+type HelpOverlay struct {
+	active bool
+	offset int
+}
+
+func (h *HelpOverlay) Open() {
+	h.active = true
+	h.offset = 0
+}
+
+func (h *HelpOverlay) Close() {
+	h.active = false
+}
+
+func (h *HelpOverlay) IsActive() bool {
+	return h.active
+}
+
+func (h *HelpOverlay) ScrollUp() {
+	if h.offset > 0 {
+		h.offset--
+	}
+}
+
+func (h *HelpOverlay) ScrollDown(termH int) {
+	if h.offset < len(helpLines())-helpListHeight(termH) {
+		h.offset++
+	}
+}
+
+// helpListHeight is the number of rows available for bindings; the border,
+// padding, title and footer take 8.
+func helpListHeight(termH int) int {
+	return max(termH-8, 3)
+}
+
+// helpSection is a group of bindings in the overlay.
+type helpSection struct {
+	title string
+	defs  []keyDef
+}
+
+// helpSections groups the keymap by context. Bindings that work in both
+// the list and the message pane are shown once under "Main view", and
+// those that work everywhere are only listed under "Everywhere".
+func helpSections() []helpSection {
+	everywhere := func(d keyDef) bool { return len(d.contexts) == len(allContexts) }
+	main := func(d keyDef) bool {
+		return slices.Contains(d.contexts, ctxList) && slices.Contains(d.contexts, ctxMessages)
+	}
+	pick := func(keep func(keyDef) bool) []keyDef {
+		var defs []keyDef
+		for _, d := range keyDefs {
+			if keep(d) {
+				defs = append(defs, d)
+			}
+		}
+		return defs
+	}
+	in := func(ctx string) func(keyDef) bool {
+		return func(d keyDef) bool { return slices.Contains(d.contexts, ctx) && !everywhere(d) }
+	}
+
+	return []helpSection{
+		{"Everywhere", pick(everywhere)},
+		{"Main view", pick(func(d keyDef) bool { return main(d) && !everywhere(d) })},
+		{"Conversation list", pick(func(d keyDef) bool { return in(ctxList)(d) && !main(d) })},
+		{"Message pane", pick(func(d keyDef) bool { return in(ctxMessages)(d) && !main(d) })},
+		{"Filter", pick(in(ctxFilter))},
+		{"Reply editor", pick(in(ctxReply))},
+		{"Mention picker", pick(in(ctxMention))},
+		{"Action palette", pick(in(ctxPalette))},
+		{"Notifications", pick(in(ctxNotifs))},
+		{"Help", pick(in(ctxHelp))},
+	}
+}
+
+// helpLines renders the sections, one binding per line.
+func helpLines() []string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	keyStyle := lipgloss.NewStyle().Foreground(colorAccent)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	keyW, descW := 0, 0
+	for _, d := range keyDefs {
+		keyW = max(keyW, lipgloss.Width(d.field(&keys).Help().Key))
+		descW = max(descW, lipgloss.Width(d.desc))
+	}
+
+	var lines []string
+	for _, s := range helpSections() {
+		if len(s.defs) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.Render(s.title))
+		for _, d := range s.defs {
+			label := d.field(&keys).Help().Key
+			if label == "" {
+				label = "—"
+			}
+			pad := strings.Repeat(" ", max(0, keyW-lipgloss.Width(label)))
+			desc := d.desc + strings.Repeat(" ", descW-lipgloss.Width(d.desc))
+			lines = append(lines, "  "+keyStyle.Render(label)+pad+"  "+desc+mutedStyle.Render("  "+d.name))
+		}
+	}
+	return lines
+}
+
+func (h *HelpOverlay) View(termW, termH int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorAccent)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	lines := helpLines()
+	listH := helpListHeight(termH)
+	h.offset = min(h.offset, max(0, len(lines)-listH))
+	end := min(h.offset+listH, len(lines))
+	body := strings.Join(lines[h.offset:end], "\n")
+	if pad := listH - (end - h.offset); pad > 0 {
+		body += strings.Repeat("\n", pad)
+	}
+
+	position := ""
+	if len(lines) > listH {
+		position = fmt.Sprintf("%d–%d of %d", h.offset+1, end, len(lines))
+	}
+	footer := mutedStyle.Render(joinHints("  ",
+		keyHint(navKeys(keys.Up, keys.Down), "scroll"),
+		keyHint(primaryKey(keys.Cancel), "close"),
+		"rebind in ~/.chatwoot/keys.yaml",
+		position))
+
+	content := titleStyle.Render("Keyboard shortcuts") + "\n\n" + body + "\n\n" + footer
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(termW - 2).
+		Render(content)
+}
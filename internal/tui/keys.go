@@ -1,101 +1,409 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"gopkg.in/yaml.v3"
 )
 
 type keyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Select  key.Binding
-	Back    key.Binding
-	Filter  key.Binding
-	Tab     key.Binding
-	Status  key.Binding
-	Refresh key.Binding
-	Open    key.Binding
-	Reply   key.Binding
-	Note    key.Binding
-	Palette key.Binding
-	Help    key.Binding
-	Quit    key.Binding
-}
-
-var keys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "down"),
-	),
-	Select: key.NewBinding(
-		key.WithKeys("enter", "right"),
-		key.WithHelp("enter/→", "select"),
-	),
-	Back: key.NewBinding(
-		key.WithKeys("left", "esc"),
-		key.WithHelp("←/esc", "back"),
-	),
-	Filter: key.NewBinding(
-		key.WithKeys("/"),
-		key.WithHelp("/", "filter"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "assignee"),
-	),
-	Status: key.NewBinding(
-		key.WithKeys("s"),
-		key.WithHelp("s", "status"),
-	),
-	Refresh: key.NewBinding(
-		key.WithKeys("r"),
-		key.WithHelp("r", "refresh"),
-	),
-	Open: key.NewBinding(
-		key.WithKeys("o"),
-		key.WithHelp("o", "open in browser"),
-	),
-	Reply: key.NewBinding(
-		key.WithKeys("R"),
-		key.WithHelp("R", "reply"),
-	),
-	Note: key.NewBinding(
-		key.WithKeys("P"),
-		key.WithHelp("P", "note"),
-	),
-	Palette: key.NewBinding(
-		key.WithKeys("ctrl+k"),
-		key.WithHelp("Ctrl+K", "actions"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "help"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
-}
-
-func helpText(hasSelection bool) string {
+	Up        key.Binding
+	Down      key.Binding
+	Select    key.Binding
+	Back      key.Binding
+	Filter    key.Binding
+	Mark      key.Binding
+	MarkRange key.Binding
+	MarkAll   key.Binding
+	Tab       key.Binding
+	Status    key.Binding
+	Refresh   key.Binding
+	Open      key.Binding
+	Reply     key.Binding
+	Note      key.Binding
+	Pager     key.Binding
+	Palette   key.Binding
+	Notifs    key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	ForceQuit key.Binding
+
+	// Layout
+	ShrinkList  key.Binding
+	GrowList    key.Binding
+	ShrinkInfo  key.Binding
+	GrowInfo    key.Binding
+	ToggleList  key.Binding
+	ToggleInfo  key.Binding
+	FocusLayout key.Binding
+
+	// Overlays and text entry
+	Confirm  key.Binding
+	Cancel   key.Binding
+	MenuUp   key.Binding
+	MenuDown key.Binding
+	Complete key.Binding
+	Send     key.Binding
+	ReadAll  key.Binding
+
+	ExternalEditor key.Binding
+}
+
+// Contexts a binding can be active in. Two bindings may only share a key
+// if they are never active at the same time.
+const (
+	ctxList     = "conversation list"
+	ctxMessages = "message pane"
+	ctxFilter   = "filter"
+	ctxReply    = "reply editor"
+	ctxMention  = "mention picker"
+	ctxPalette  = "action palette"
+	ctxNotifs   = "notifications"
+	ctxHelp     = "help"
+)
+
+// textContexts take typed text, so plain characters can't be bound there.
+var textContexts = []string{ctxFilter, ctxReply, ctxMention, ctxPalette}
+
+// keyDef describes one binding: its name in keys.yaml, default keys and
+// where it is active.
+type keyDef struct {
+	name     string
+	desc     string
+	keys     []string
+	contexts []string
+	field    func(*keyMap) *key.Binding
+}
+
+var (
+	globalContexts = []string{ctxList, ctxMessages}
+	allContexts    = []string{ctxList, ctxMessages, ctxFilter, ctxReply, ctxMention, ctxPalette, ctxNotifs, ctxHelp}
+)
+
+var keyDefs = []keyDef{
+	{"up", "up", []string{"up", "k"}, []string{ctxList, ctxMessages, ctxNotifs, ctxHelp}, func(k *keyMap) *key.Binding { return &k.Up }},
+	{"down", "down", []string{"down", "j"}, []string{ctxList, ctxMessages, ctxNotifs, ctxHelp}, func(k *keyMap) *key.Binding { return &k.Down }},
+	{"select", "open conversation / info", []string{"enter", "right"}, []string{ctxList, ctxMessages}, func(k *keyMap) *key.Binding { return &k.Select }},
+	{"back", "back / clear marks", []string{"esc", "left"}, []string{ctxList, ctxMessages}, func(k *keyMap) *key.Binding { return &k.Back }},
+	{"filter", "filter", []string{"/"}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.Filter }},
+	{"mark", "mark", []string{" "}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.Mark }},
+	{"mark_range", "mark range", []string{"V"}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.MarkRange }},
+	{"mark_all", "mark all", []string{"*"}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.MarkAll }},
+	{"tab", "next tab", []string{"tab"}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.Tab }},
+	{"status", "status", []string{"s"}, []string{ctxList}, func(k *keyMap) *key.Binding { return &k.Status }},
+	{"reply", "reply", []string{"R"}, []string{ctxMessages}, func(k *keyMap) *key.Binding { return &k.Reply }},
+	{"note", "note", []string{"P"}, []string{ctxMessages}, func(k *keyMap) *key.Binding { return &k.Note }},
+	{"pager", "transcript in $PAGER", []string{"v"}, []string{ctxMessages}, func(k *keyMap) *key.Binding { return &k.Pager }},
+	{"shrink_list", "narrower list", []string{"["}, globalContexts, func(k *keyMap) *key.Binding { return &k.ShrinkList }},
+	{"grow_list", "wider list", []string{"]"}, globalContexts, func(k *keyMap) *key.Binding { return &k.GrowList }},
+	{"shrink_info", "narrower info pane", []string{"{"}, globalContexts, func(k *keyMap) *key.Binding { return &k.ShrinkInfo }},
+	{"grow_info", "wider info pane", []string{"}"}, globalContexts, func(k *keyMap) *key.Binding { return &k.GrowInfo }},
+	{"toggle_list", "hide/show list", []string{"L"}, globalContexts, func(k *keyMap) *key.Binding { return &k.ToggleList }},
+	{"toggle_info", "hide/show info pane", []string{"I"}, globalContexts, func(k *keyMap) *key.Binding { return &k.ToggleInfo }},
+	{"focus_layout", "one pane at a time", []string{"z"}, globalContexts, func(k *keyMap) *key.Binding { return &k.FocusLayout }},
+	{"refresh", "refresh", []string{"r"}, globalContexts, func(k *keyMap) *key.Binding { return &k.Refresh }},
+	{"open", "open in browser", []string{"o"}, globalContexts, func(k *keyMap) *key.Binding { return &k.Open }},
+	{"palette", "actions", []string{"ctrl+k"}, globalContexts, func(k *keyMap) *key.Binding { return &k.Palette }},
+	{"notifications", "notifications", []string{"n"}, []string{ctxList, ctxMessages, ctxNotifs}, func(k *keyMap) *key.Binding { return &k.Notifs }},
+	{"help", "help", []string{"?"}, []string{ctxList, ctxMessages, ctxHelp}, func(k *keyMap) *key.Binding { return &k.Help }},
+	{"quit", "quit", []string{"q"}, globalContexts, func(k *keyMap) *key.Binding { return &k.Quit }},
+	{"force_quit", "quit from anywhere", []string{"ctrl+c"}, allContexts, func(k *keyMap) *key.Binding { return &k.ForceQuit }},
+	{"confirm", "confirm", []string{"enter"}, []string{ctxFilter, ctxPalette, ctxNotifs}, func(k *keyMap) *key.Binding { return &k.Confirm }},
+	{"cancel", "close", []string{"esc"}, []string{ctxFilter, ctxReply, ctxMention, ctxPalette, ctxNotifs, ctxHelp}, func(k *keyMap) *key.Binding { return &k.Cancel }},
+	{"menu_up", "previous item", []string{"up"}, []string{ctxPalette, ctxMention}, func(k *keyMap) *key.Binding { return &k.MenuUp }},
+	{"menu_down", "next item", []string{"down"}, []string{ctxPalette, ctxMention}, func(k *keyMap) *key.Binding { return &k.MenuDown }},
+	{"complete", "insert mention", []string{"enter", "tab"}, []string{ctxMention}, func(k *keyMap) *key.Binding { return &k.Complete }},
+	{"send", "send", []string{"ctrl+s"}, []string{ctxReply, ctxMention}, func(k *keyMap) *key.Binding { return &k.Send }},
+	{"external_editor", "edit in $EDITOR", []string{"ctrl+e"}, []string{ctxReply, ctxMention}, func(k *keyMap) *key.Binding { return &k.ExternalEditor }},
+	{"read_all", "mark all read", []string{"a"}, []string{ctxNotifs}, func(k *keyMap) *key.Binding { return &k.ReadAll }},
+}
+
+// keyPresets replace the default keys of some bindings.
+var keyPresets = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"select":    {"enter", "right", "l"},
+		"back":      {"esc", "left", "h"},
+		"palette":   {"ctrl+k", ":"},
+		"menu_up":   {"up", "ctrl+p"},
+		"menu_down": {"down", "ctrl+n"},
+		"complete":  {"enter", "tab", "ctrl+y"},
+	},
+	"emacs": {
+		"up":        {"up", "ctrl+p"},
+		"down":      {"down", "ctrl+n"},
+		"select":    {"enter", "right", "ctrl+f"},
+		"back":      {"esc", "left", "ctrl+b"},
+		"filter":    {"/", "ctrl+s"},
+		"palette":   {"ctrl+k", "alt+x"},
+		"cancel":    {"esc", "ctrl+g"},
+		"menu_up":   {"up", "ctrl+p"},
+		"menu_down": {"down", "ctrl+n"},
+	},
+}
+
+// keys is the active keymap. Run replaces it with the one from keys.yaml.
+var keys, _ = newKeyMap(keyFile{})
+
+// keyFile is the format of ~/.chatwoot/keys.yaml:
+//
+//	preset: vim
+//	bindings:
+//	  refresh: ctrl+r
+//	  reply: [R, ctrl+r]
+type keyFile struct {
+	Preset   string             `yaml:"preset"`
+	Bindings map[string]keyList `yaml:"bindings"`
+}
+
+// keyList accepts a single key or a list of keys.
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = keyList{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+func keysPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keys.yaml"), nil
+}
+
+// loadKeyMap builds the keymap from keys.yaml, or the defaults if the file
+// doesn't exist.
+func loadKeyMap() (keyMap, error) {
+	path, err := keysPath()
+	if err != nil {
+		return keyMap{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return newKeyMap(keyFile{})
+		}
+		return keyMap{}, fmt.Errorf("failed to read keys: %w", err)
+	}
+
+	var f keyFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return keyMap{}, fmt.Errorf("failed to parse keys: %w", err)
+	}
+	km, err := newKeyMap(f)
+	if err != nil {
+		return keyMap{}, fmt.Errorf("invalid keys in %s: %w", path, err)
+	}
+	return km, nil
+}
+
+// newKeyMap applies a preset and then the file's bindings to the default
+// keys, and checks that no key does two things at once.
+func newKeyMap(f keyFile) (keyMap, error) {
+	preset := f.Preset
+	if preset == "" {
+		preset = "default"
+	}
+	overrides, ok := keyPresets[preset]
+	if !ok {
+		return keyMap{}, fmt.Errorf("unknown preset %q (use default, vim or emacs)", f.Preset)
+	}
+
+	bound := map[string][]string{}
+	for _, d := range keyDefs {
+		bound[d.name] = d.keys
+		if k, ok := overrides[d.name]; ok {
+			bound[d.name] = k
+		}
+	}
+	for name, list := range f.Bindings {
+		if _, ok := bound[name]; !ok {
+			return keyMap{}, fmt.Errorf("unknown binding %q", name)
+		}
+		if len(list) == 0 && (name == "cancel" || name == "force_quit") {
+			return keyMap{}, fmt.Errorf("%s can't be unbound", name)
+		}
+		normalized := make([]string, len(list))
+		for i, k := range list {
+			if k == "space" {
+				k = " "
+			}
+			normalized[i] = k
+		}
+		bound[name] = normalized
+	}
+
+	// context -> key -> binding name
+	used := map[string]map[string]string{}
+	var km keyMap
+	for _, d := range keyDefs {
+		list := bound[d.name]
+		for _, ctx := range d.contexts {
+			if used[ctx] == nil {
+				used[ctx] = map[string]string{}
+			}
+			for _, k := range list {
+				if other, ok := used[ctx][k]; ok && other != d.name {
+					return keyMap{}, fmt.Errorf("%q is bound to both %s and %s in the %s", k, other, d.name, ctx)
+				}
+				used[ctx][k] = d.name
+				if utf8.RuneCountInString(k) == 1 && isTextContext(ctx) {
+					return keyMap{}, fmt.Errorf("%s: %q would be typed into the %s", d.name, k, ctx)
+				}
+			}
+		}
+		*d.field(&km) = key.NewBinding(
+			key.WithKeys(list...),
+			key.WithHelp(keysLabel(list), d.desc),
+		)
+	}
+	return km, nil
+}
+
+func isTextContext(ctx string) bool {
+	for _, c := range textContexts {
+		if c == ctx {
+			return true
+		}
+	}
+	return false
+}
+
+// keyLabel is how a key is shown in help, e.g. "Ctrl+K" or "↑".
+func keyLabel(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "Space"
+	case "enter", "esc", "tab", "backspace", "delete", "home", "end":
+		return strings.ToUpper(k[:1]) + k[1:]
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	}
+	if i := strings.LastIndex(k, "+"); i > 0 && i < len(k)-1 {
+		mods := strings.Split(k[:i], "+")
+		for j, m := range mods {
+			mods[j] = strings.ToUpper(m[:1]) + m[1:]
+		}
+		return strings.Join(mods, "+") + "+" + strings.ToUpper(k[i+1:])
+	}
+	return k
+}
+
+func keysLabel(list []string) string {
+	labels := make([]string, len(list))
+	for i, k := range list {
+		labels[i] = keyLabel(k)
+	}
+	return strings.Join(labels, "/")
+}
+
+// primaryKey is the label of a binding's first key, used in the short
+// hints of the footer and overlays.
+func primaryKey(b key.Binding) string {
+	if len(b.Keys()) == 0 {
+		return ""
+	}
+	return keyLabel(b.Keys()[0])
+}
+
+// navKeys labels a pair of up/down bindings, e.g. "↑↓" or "k/j".
+func navKeys(up, down key.Binding) string {
+	u, d := primaryKey(up), primaryKey(down)
+	if u == "" || d == "" {
+		return u + d
+	}
+	if isArrow(u) && isArrow(d) {
+		return u + d
+	}
+	return u + "/" + d
+}
+
+func isArrow(label string) bool {
+	return label == "↑" || label == "↓" || label == "←" || label == "→"
+}
+
+// keyHint is a "key description" hint for overlay footers, or "" when the
+// key is unbound.
+func keyHint(label, desc string) string {
+	if label == "" {
+		return ""
+	}
+	return label + " " + desc
+}
+
+// joinHints joins the non-empty hints with sep.
+func joinHints(sep string, hints ...string) string {
+	var parts []string
+	for _, h := range hints {
+		if h != "" {
+			parts = append(parts, h)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func helpText(hasSelection bool, marked int) string {
 	k := lipgloss.NewStyle().Foreground(colorAccent)
 	l := lipgloss.NewStyle().Foreground(colorMuted)
 
-	text := k.Render("↑↓") + l.Render(" navigate  ") +
-		k.Render("/") + l.Render(" filter  ") +
-		k.Render("Tab") + l.Render(" assignee  ") +
-		k.Render("s") + l.Render(" status  ")
+	var hints []string
+	hint := func(key, desc string) {
+		if key != "" {
+			hints = append(hints, k.Render(key)+l.Render(" "+desc))
+		}
+	}
+
+	if marked > 0 {
+		hint(primaryKey(keys.Palette), "actions")
+		hint(primaryKey(keys.Mark), "mark")
+		hint(primaryKey(keys.MarkRange), "range")
+		hint(primaryKey(keys.MarkAll), "all")
+		hint(primaryKey(keys.Back), "clear")
+		return lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render(fmt.Sprintf("%d selected  ", marked)) +
+			strings.Join(hints, "  ")
+	}
+
+	hint(navKeys(keys.Up, keys.Down), "navigate")
+	hint(primaryKey(keys.Filter), "filter")
+	hint(primaryKey(keys.Tab), "next tab")
+	hint(primaryKey(keys.Status), "status")
 	if hasSelection {
-		text += k.Render("Ctrl+K") + l.Render(" actions  ") +
-			k.Render("R") + l.Render(" reply  ") +
-			k.Render("P") + l.Render(" note  ") +
-			k.Render("o") + l.Render(" open  ")
-	}
-	text += k.Render("r") + l.Render(" refresh  ") +
-		k.Render("q") + l.Render(" quit")
-	return text
+		hint(primaryKey(keys.Mark), "mark")
+		hint(primaryKey(keys.Palette), "actions")
+		hint(primaryKey(keys.Reply), "reply")
+		hint(primaryKey(keys.Note), "note")
+		hint(primaryKey(keys.Open), "open")
+	}
+	hint(primaryKey(keys.Notifs), "notifications")
+	hint(primaryKey(keys.Refresh), "refresh")
+	hint(primaryKey(keys.Help), "help")
+	hint(primaryKey(keys.Quit), "quit")
+	return strings.Join(hints, "  ")
 }
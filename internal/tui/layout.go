@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Pane width limits and steps (content width, not including borders)
+const (
+	minPaneWidth  = 20
+	maxInfoWidth  = 60
+	minMsgWidth   = 20
+	paneWidthStep = 4
+
+	// Below this terminal width only one pane is shown at a time
+	focusLayoutWidth = 60
+)
+
+// Layout holds the pane preferences, saved to ~/.chatwoot/layout.yaml
+// whenever they change.
+type Layout struct {
+	ConvWidth int  `yaml:"conv_width,omitempty"`
+	InfoWidth int  `yaml:"info_width,omitempty"`
+	HideList  bool `yaml:"hide_list,omitempty"` // list only shows while focused
+	HideInfo  bool `yaml:"hide_info,omitempty"`
+	Focus     bool `yaml:"focus,omitempty"` // one pane at a time at any width
+}
+
+func defaultLayout() Layout {
+	return Layout{ConvWidth: convPaneWidth, InfoWidth: infoPaneWidth}
+}
+
+func layoutPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "layout.yaml"), nil
+}
+
+// loadLayout returns the saved layout, or the default one if none was saved.
+func loadLayout() (Layout, error) {
+	l := defaultLayout()
+	path, err := layoutPath()
+	if err != nil {
+		return l, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return l, fmt.Errorf("failed to read layout: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return l, fmt.Errorf("failed to parse layout %s: %w", path, err)
+	}
+	if l.ConvWidth < minPaneWidth {
+		l.ConvWidth = convPaneWidth
+	}
+	if l.InfoWidth < minPaneWidth {
+		l.InfoWidth = infoPaneWidth
+	}
+	return l, nil
+}
+
+type layoutSavedMsg struct {
+	err error
+}
+
+// saveLayout writes the layout in the background.
+func saveLayout(l Layout) tea.Cmd {
+	return func() tea.Msg {
+		path, err := layoutPath()
+		if err != nil {
+			return layoutSavedMsg{err}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return layoutSavedMsg{fmt.Errorf("failed to create config directory: %w", err)}
+		}
+		data, err := yaml.Marshal(l)
+		if err != nil {
+			return layoutSavedMsg{fmt.Errorf("failed to encode layout: %w", err)}
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return layoutSavedMsg{fmt.Errorf("failed to write layout: %w", err)}
+		}
+		return layoutSavedMsg{}
+	}
+}
+
+// ResizeList changes the list width by delta, keeping room for the
+// message pane at the given terminal width.
+func (l *Layout) ResizeList(delta, termW int) {
+	maxW := termW - l.InfoWidth - minMsgWidth - 6
+	if l.HideInfo {
+		maxW = termW - minMsgWidth - 4
+	}
+	l.ConvWidth = clamp(l.ConvWidth+delta, minPaneWidth, max(minPaneWidth, maxW))
+}
+
+// ResizeInfo changes the info pane width by delta.
+func (l *Layout) ResizeInfo(delta, termW int) {
+	maxW := min(maxInfoWidth, termW-l.ConvWidth-minMsgWidth-6)
+	l.InfoWidth = clamp(l.InfoWidth+delta, minPaneWidth, max(minPaneWidth, maxW))
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
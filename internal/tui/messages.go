@@ -2,38 +2,18 @@ package tui
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/glamour"
-	"github.com/charmbracelet/glamour/ansi"
-	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/markdown"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
-	"github.com/muesli/termenv"
 )
 
-// mentionRe matches [@Name](mention://user/ID/Name) or [@Name](mention://team/ID/Name)
-// and captures the display text (the part inside [ ]).
-var mentionRe = regexp.MustCompile(`\[@([^\]]+)\]\(mention://(?:user|team)/\d+/[^)]+\)`)
-
-// chatStyleConfig returns a glamour style tuned for chat bubbles: no document
-// margin/indent and no block prefix/suffix newlines. Detected once at startup
-// before bubbletea takes over the terminal (WithAutoStyle deadlocks).
-var chatStyleConfig = func() glamour.TermRendererOption {
-	var cfg ansi.StyleConfig
-	if termenv.HasDarkBackground() {
-		cfg = styles.DarkStyleConfig
-	} else {
-		cfg = styles.LightStyleConfig
-	}
-	// Strip document-level whitespace — content lives inside a lipgloss box
-	zero := uint(0)
-	cfg.Document.Margin = &zero
-	cfg.Document.BlockPrefix = ""
-	cfg.Document.BlockSuffix = ""
-	return glamour.WithStyles(cfg)
-}()
+// chatStyle is the glamour style for chat bubbles: no document margin/indent
+// and no block prefix/suffix newlines. Run sets it from the theme, detecting
+// the background before bubbletea takes over the terminal.
+var chatStyle = markdown.ChatStyle(markdown.BaseStyle(true))
 
 // MessagePane renders the messages for the selected conversation.
 // Messages are only loaded when the user presses Enter.
@@ -46,6 +26,7 @@ type MessagePane struct {
 	oldestMessageID  int
 	width, height    int
 	scrollOffset     int
+	typing           string // name of whoever is typing, shown below the messages
 	mdRenderer       *glamour.TermRenderer
 }
 
@@ -66,16 +47,16 @@ func (p *MessagePane) SetSize(w, h int) {
 	if textW < 5 {
 		textW = 5
 	}
-	r, err := glamour.NewTermRenderer(
-		chatStyleConfig,
-		glamour.WithWordWrap(textW),
-	)
+	r, err := markdown.NewRenderer(chatStyle, textW)
 	if err == nil {
 		p.mdRenderer = r
 	}
 }
 
 func (p *MessagePane) SetMessages(convID int, msgs []sdk.Message) {
+	if convID != p.conversationID {
+		p.typing = ""
+	}
 	p.conversationID = convID
 	p.messages = msgs
 	p.loaded = true
@@ -98,6 +79,40 @@ func (p *MessagePane) Clear() {
 	p.conversationID = 0
 	p.loaded = false
 	p.scrollOffset = 0
+	p.typing = ""
+}
+
+// UpsertMessage adds a message pushed in real time, or replaces it if it
+// is already shown (e.g. a delivery status update). The view stays pinned to
+// the bottom if it already was.
+func (p *MessagePane) UpsertMessage(msg sdk.Message) {
+	for i := range p.messages {
+		if p.messages[i].ID == msg.ID {
+			p.messages[i] = msg
+			return
+		}
+	}
+
+	atBottom := p.scrollOffset >= p.countLines()-p.height
+	p.messages = append(p.messages, msg)
+	p.typing = ""
+	if atBottom {
+		p.scrollToBottom()
+	}
+}
+
+// MergeMessages applies a reload of the latest page to the open
+// conversation: shown messages are updated and new ones appended, keeping
+// the scroll position (or the pin to the bottom).
+func (p *MessagePane) MergeMessages(msgs []sdk.Message) {
+	for _, msg := range msgs {
+		p.UpsertMessage(msg)
+	}
+}
+
+// SetTyping shows (or with an empty name, hides) a typing indicator.
+func (p *MessagePane) SetTyping(name string) {
+	p.typing = name
 }
 
 func (p *MessagePane) IsLoaded() bool {
@@ -163,6 +178,15 @@ func (p *MessagePane) ScrollDown() {
 	}
 }
 
+// Transcript renders msgs as the pane shows them, for a pager.
+func (p *MessagePane) Transcript(msgs []sdk.Message) string {
+	var lines []string
+	for _, msg := range msgs {
+		lines = append(lines, p.renderMessage(msg)...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func (p *MessagePane) countLines() int {
 	n := 0
 	for _, msg := range p.messages {
@@ -175,7 +199,7 @@ func (p *MessagePane) countLines() int {
 // CRITICAL: output is strictly bounded to p.height lines to prevent overflow.
 func (p *MessagePane) View() string {
 	if !p.loaded {
-		logo := lipgloss.NewStyle().Foreground(lipgloss.Color("#1f93ff")).Render(chatwootLogo)
+		logo := lipgloss.NewStyle().Foreground(colorLogo).Render(chatwootLogo)
 		hint := lipgloss.NewStyle().Foreground(colorMuted).Render("Press Enter to load messages")
 		return lipgloss.NewStyle().
 			Width(p.width).Height(p.height).
@@ -191,6 +215,12 @@ func (p *MessagePane) View() string {
 			Render("No messages")
 	}
 
+	// Reserve the last line for the typing indicator
+	height := p.height
+	if p.typing != "" {
+		height--
+	}
+
 	// Render each message into lines, collect into flat slice.
 	var lines []string
 	for _, msg := range p.messages {
@@ -198,19 +228,24 @@ func (p *MessagePane) View() string {
 		lines = append(lines, rendered...)
 	}
 	// Apply scroll and clamp to height
-	if p.scrollOffset > len(lines)-p.height {
-		p.scrollOffset = len(lines) - p.height
+	if p.scrollOffset > len(lines)-height {
+		p.scrollOffset = len(lines) - height
 	}
 	if p.scrollOffset < 0 {
 		p.scrollOffset = 0
 	}
 
-	end := p.scrollOffset + p.height
+	end := p.scrollOffset + height
 	if end > len(lines) {
 		end = len(lines)
 	}
 	visible := lines[p.scrollOffset:end]
 
+	if p.typing != "" {
+		visible = append(visible, lipgloss.NewStyle().Foreground(colorMuted).Italic(true).
+			Render(p.typing+" is typing…"))
+	}
+
 	return strings.Join(visible, "\n")
 }
 
@@ -248,7 +283,7 @@ func (p *MessagePane) renderMessage(msg sdk.Message) []string {
 		sender = msg.Sender.Name
 	}
 
-	ts := formatTime(msg.CreatedAt)
+	ts := timeFormat.Short(msg.CreatedAt)
 	statusIcon := msgStatus(msg.Status, msg.MessageType)
 
 	content := strings.TrimSpace(msg.Content)
@@ -257,7 +292,7 @@ func (p *MessagePane) renderMessage(msg sdk.Message) []string {
 	}
 
 	// Strip mention URLs: [@Name](mention://...) → **@Name** (bold)
-	content = mentionRe.ReplaceAllString(content, "**@$1**")
+	content = markdown.ReplaceMentions(content)
 
 	// Render markdown
 	if p.mdRenderer != nil {
@@ -266,6 +301,9 @@ func (p *MessagePane) renderMessage(msg sdk.Message) []string {
 			content = strings.TrimRight(rendered, "\n")
 		}
 	}
+	if hyperlinks {
+		content = linkify(content)
+	}
 
 	// Metadata line below box: [sender ·] #ID · time [· status]
 	// Skip sender for incoming messages (type 0) — visible in info pane
@@ -282,7 +320,7 @@ func (p *MessagePane) renderMessage(msg sdk.Message) []string {
 	metaStyle := lipgloss.NewStyle().Foreground(colorMuted)
 
 	// Build the box (content only)
-	var borderColor lipgloss.AdaptiveColor
+	var borderColor lipgloss.TerminalColor
 	if msg.Private {
 		borderColor = colorPrivate
 	} else if msg.MessageType == 1 {
@@ -330,7 +368,7 @@ func msgStatus(status string, messageType int) string {
 	case "read":
 		return lipgloss.NewStyle().Foreground(colorAccent).Render("✔︎✔︎")
 	case "failed":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Render("●")
+		return lipgloss.NewStyle().Foreground(colorError).Render("●")
 	default:
 		return ""
 	}
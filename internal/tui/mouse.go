@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Mouse support is opt-in (mouse: true in config.yaml) because capturing
+// the mouse stops terminals from selecting text without a modifier key.
+
+// wheelLines is how far one wheel step scrolls the message pane.
+const wheelLines = 3
+
+// bodyTop is the screen row of the first content line in the body
+// columns: below the header (3 lines) and the columns' top border.
+const bodyTop = 4
+
+// hyperlinks turns URLs in messages into OSC 8 links. Run enables it
+// together with the mouse.
+var hyperlinks bool
+
+// urlRe matches a URL up to whitespace or the next escape sequence.
+var urlRe = regexp.MustCompile(`https?://[^\s\x1b]+`)
+
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.reply.IsActive() || m.palette.IsActive() || m.notifPanel.IsActive() || m.convList.IsFiltering() {
+		return m, nil
+	}
+	wheel := msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown
+	dir := 1
+	if msg.Button == tea.MouseButtonWheelUp {
+		dir = -1
+	}
+
+	if m.help.IsActive() {
+		if dir < 0 {
+			m.help.ScrollUp()
+		} else if wheel {
+			m.help.ScrollDown(m.height)
+		}
+		return m, nil
+	}
+
+	pane := m.paneAt(msg.X)
+	row := msg.Y - bodyTop
+	if wheel {
+		switch pane {
+		case 0:
+			cmd := m.moveCursor(dir)
+			return m, cmd
+		case 1:
+			cmd := m.scrollMessages(dir * wheelLines)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress ||
+		row < 0 || row >= m.bodyHeight() {
+		return m, nil
+	}
+	switch pane {
+	case 0:
+		return m.clickList(msg.X-1, row, msg.Ctrl)
+	case 1:
+		if m.msgPane.IsLoaded() {
+			m.activePane = 1
+		}
+	case 2:
+		if m.focusLayout() {
+			m.activePane = 2
+		}
+	}
+	return m, nil
+}
+
+// clickList handles a click at column x and row of the list's content:
+// the tab line switches tabs or status, a conversation row opens it, or
+// marks it with Ctrl held.
+func (m Model) clickList(x, row int, ctrl bool) (tea.Model, tea.Cmd) {
+	m.activePane = 0
+	switch {
+	case row == 0:
+		tab, status := m.convList.TabAt(x)
+		switch {
+		case tab >= 0:
+			m.convList.SetTab(tab)
+		case status && m.convList.ActiveView() == nil:
+			m.convList.CycleStatus()
+		default:
+			return m, nil
+		}
+		cmd := m.filterChanged()
+		return m, cmd
+	case row >= listHeaderRows:
+		if !m.convList.Click(row - listHeaderRows) {
+			return m, nil
+		}
+		if ctrl {
+			m.convList.ToggleMark()
+			return m, nil
+		}
+		m.syncMessagePane()
+		if cmd, ok := m.openSelected(); ok {
+			return m, tea.Batch(cmd, m.fetchContactIfNeeded())
+		}
+	}
+	return m, nil
+}
+
+// paneAt returns the pane under screen column x: 0 for the list, 1 for
+// messages, 2 for info, or -1.
+func (m Model) paneAt(x int) int {
+	convW, msgW, infoW := m.columnWidths()
+	edge := 0
+	for pane, w := range []int{convW, msgW, infoW} {
+		if w == 0 {
+			continue
+		}
+		edge += w + 2 // border
+		if x < edge {
+			return pane
+		}
+	}
+	return -1
+}
+
+// linkify wraps the URLs in rendered text in OSC 8 hyperlinks, which
+// terminals make clickable (often with Ctrl or Cmd held while the mouse
+// is captured). A URL wrapped onto two lines only links its first part.
+func linkify(text string) string {
+	return urlRe.ReplaceAllStringFunc(text, func(url string) string {
+		trimmed := strings.TrimRight(url, ".,;:!?)]}'\"")
+		return "\x1b]8;;" + trimmed + "\x1b\\" + trimmed + "\x1b]8;;\x1b\\" + url[len(trimmed):]
+	})
+}
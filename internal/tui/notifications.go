@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// NotificationPanel is an overlay listing the agent's Chatwoot
+// notifications. Selecting one marks it read and opens its conversation.
+type NotificationPanel struct {
+	active        bool
+	loading       bool
+	err           error
+	notifications []sdk.Notification
+	cursor        int
+	scrollOffset  int
+}
+
+func NewNotificationPanel() NotificationPanel {
+	return NotificationPanel{}
+}
+
+func (p *NotificationPanel) Open() {
+	p.active = true
+	p.loading = true
+	p.err = nil
+	p.cursor = 0
+	p.scrollOffset = 0
+}
+
+func (p *NotificationPanel) Close() {
+	p.active = false
+}
+
+func (p *NotificationPanel) IsActive() bool {
+	return p.active
+}
+
+func (p *NotificationPanel) SetNotifications(notifications []sdk.Notification, err error) {
+	p.loading = false
+	p.err = err
+	p.notifications = notifications
+	if p.cursor >= len(notifications) {
+		p.cursor = max(0, len(notifications)-1)
+	}
+}
+
+func (p *NotificationPanel) MoveUp() {
+	if p.cursor > 0 {
+		p.cursor--
+	}
+}
+
+func (p *NotificationPanel) MoveDown() {
+	if p.cursor < len(p.notifications)-1 {
+		p.cursor++
+	}
+}
+
+func (p *NotificationPanel) Selected() *sdk.Notification {
+	if len(p.notifications) == 0 {
+		return nil
+	}
+	return &p.notifications[p.cursor]
+}
+
+// MarkRead flags a notification as read locally, ahead of the API call.
+func (p *NotificationPanel) MarkRead(id int) {
+	for i := range p.notifications {
+		if p.notifications[i].ID == id && p.notifications[i].ReadAt == nil {
+			p.notifications[i].ReadAt = true
+		}
+	}
+}
+
+func (p *NotificationPanel) MarkAllRead() {
+	for i := range p.notifications {
+		if p.notifications[i].ReadAt == nil {
+			p.notifications[i].ReadAt = true
+		}
+	}
+}
+
+func (p *NotificationPanel) View(termW, termH int) string {
+	boxW := termW * 70 / 100
+	if boxW < 40 {
+		boxW = 40
+	}
+	textW := boxW - 4 // minus horizontal padding
+
+	// Leave room for the border, padding, title, footer and a margin
+	// around the overlay
+	listH := termH - 12
+	if listH < 3 {
+		listH = 3
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render("Notifications")
+
+	var list string
+	switch {
+	case p.loading:
+		list = lipgloss.NewStyle().Foreground(colorMuted).Render("  Loading...")
+	case p.err != nil:
+		list = errorStyle.Render(fmt.Sprintf("  Error: %v", p.err))
+	case len(p.notifications) == 0:
+		list = lipgloss.NewStyle().Foreground(colorMuted).Render("  No notifications")
+	default:
+		if p.cursor < p.scrollOffset {
+			p.scrollOffset = p.cursor
+		} else if p.cursor >= p.scrollOffset+listH {
+			p.scrollOffset = p.cursor - listH + 1
+		}
+		end := min(p.scrollOffset+listH, len(p.notifications))
+
+		var b strings.Builder
+		for i := p.scrollOffset; i < end; i++ {
+			b.WriteString(p.renderRow(p.notifications[i], i == p.cursor, textW))
+			if i < end-1 {
+				b.WriteString("\n")
+			}
+		}
+		list = b.String()
+	}
+
+	footer := lipgloss.NewStyle().Foreground(colorMuted).
+		Render(joinHints("  ",
+			keyHint(navKeys(keys.Up, keys.Down), "navigate"),
+			keyHint(primaryKey(keys.Confirm), "open"),
+			keyHint(primaryKey(keys.ReadAll), "mark all read"),
+			keyHint(primaryKey(keys.Cancel), "close")))
+
+	content := title + "\n\n" + list + "\n\n" + footer
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(boxW).
+		Render(content)
+}
+
+func (p *NotificationPanel) renderRow(n sdk.Notification, selected bool, w int) string {
+	dot := " "
+	if !n.IsRead() {
+		dot = lipgloss.NewStyle().Foreground(colorAccent).Render("●")
+	}
+	ts := timeFormat.Short(n.CreatedAt)
+	title := n.PushMessageTitle
+	if title == "" {
+		title = strings.ReplaceAll(n.NotificationType, "_", " ")
+	}
+	title = truncate(title, w-lipgloss.Width(ts)-5)
+
+	gap := w - 4 - lipgloss.Width(title) - lipgloss.Width(ts)
+	if gap < 1 {
+		gap = 1
+	}
+	style := lipgloss.NewStyle()
+	if n.IsRead() {
+		style = style.Foreground(colorMuted)
+	}
+	row := style.Render(title) + strings.Repeat(" ", gap) + convSnippetStyle.Render(ts)
+
+	if selected {
+		return lipgloss.NewStyle().Bold(true).Background(colorSelected).Render("> " + dot + " " + row)
+	}
+	return "  " + dot + " " + row
+}
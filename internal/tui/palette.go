@@ -1,22 +1,42 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
 	"github.com/sahilm/fuzzy"
 )
 
 // PaletteAction represents a single action in the command palette.
 type PaletteAction struct {
 	Label        string
-	Action       string // "toggle_status", "open_browser", "refresh", "quit"
-	Status       string // target status (only for toggle_status)
+	Action       string // "toggle_status", "set_priority", "assign", "assign_team", "add_label", "remove_label", "set_availability", "open_browser", "notifications", "help", "refresh", "quit"
+	Status       string // target status (toggle_status) or availability (set_availability)
+	Priority     string // target priority (only for set_priority)
+	AssigneeID   int    // agent to assign, 0 to unassign (only for assign)
+	TeamID       int    // team to assign, 0 to remove (only for assign_team)
+	Tag          string // conversation label to add or remove
 	SnoozedUntil *int64 // nil except for snooze actions
 	Icon         string // display icon/dot
+
+	// Children turns the action into a submenu: selecting it shows these
+	// actions instead, and Esc returns to the parent menu.
+	Children []PaletteAction
+}
+
+// paletteContext is what the palette needs to know besides its targets.
+type paletteContext struct {
+	availability string
+	userID       int
+	agents       []sdk.AgentFull
+	teams        []sdk.TeamFull
+	labels       []string // account labels, offered in the labels picker
 }
 
 // Palette is a command-K style action picker overlay with fuzzy search.
@@ -25,15 +45,26 @@ type Palette struct {
 	allActions []PaletteAction
 	filtered   []PaletteAction
 	cursor     int
-	convID     int
+	targets    []int // conversations the actions apply to
 	input      textinput.Model
+
+	title string         // label of the open submenu, empty at the top level
+	stack []paletteLevel // parent menus of the open submenu, innermost last
+}
+
+type paletteLevel struct {
+	title   string
+	actions []PaletteAction
+	cursor  int
 }
 
 func NewPalette() Palette {
 	return Palette{}
 }
 
-func (p *Palette) Open(convID int, currentStatus string) tea.Cmd {
+// Open shows the actions for targets: the selected conversation, or every
+// marked one. Actions that wouldn't change any target are left out.
+func (p *Palette) Open(targets []sdk.Conversation, ctx paletteContext) tea.Cmd {
 	ti := textinput.New()
 	ti.Placeholder = "Type to filter..."
 	ti.Prompt = "> "
@@ -41,24 +72,43 @@ func (p *Palette) Open(convID int, currentStatus string) tea.Cmd {
 	ti.Focus()
 
 	p.active = true
-	p.convID = convID
 	p.cursor = 0
 	p.input = ti
+	p.title = ""
+	p.stack = nil
+	p.targets = nil
+	for _, conv := range targets {
+		p.targets = append(p.targets, conv.ID)
+	}
+	single := len(targets) == 1
+
+	// needs reports whether some target doesn't satisfy has yet
+	needs := func(has func(sdk.Conversation) bool) bool {
+		for _, conv := range targets {
+			if !has(conv) {
+				return true
+			}
+		}
+		return false
+	}
+	status := func(s string) bool {
+		return needs(func(c sdk.Conversation) bool { return c.Status == s })
+	}
 
 	// Build actions based on current status
 	p.allActions = nil
 
 	// Status actions
-	if currentStatus != "open" {
+	if status("open") {
 		p.allActions = append(p.allActions, PaletteAction{Label: "Reopen", Action: "toggle_status", Status: "open", Icon: statusDot("open")})
 	}
-	if currentStatus != "resolved" {
+	if status("resolved") {
 		p.allActions = append(p.allActions, PaletteAction{Label: "Mark as resolved", Action: "toggle_status", Status: "resolved", Icon: statusDot("resolved")})
 	}
-	if currentStatus != "pending" {
+	if status("pending") {
 		p.allActions = append(p.allActions, PaletteAction{Label: "Mark as pending", Action: "toggle_status", Status: "pending", Icon: statusDot("pending")})
 	}
-	if currentStatus != "snoozed" {
+	if status("snoozed") {
 		dot := statusDot("snoozed")
 		p.allActions = append(p.allActions,
 			PaletteAction{Label: "Snooze until next reply", Action: "toggle_status", Status: "snoozed", SnoozedUntil: nil, Icon: dot},
@@ -67,9 +117,88 @@ func (p *Palette) Open(convID int, currentStatus string) tea.Cmd {
 		)
 	}
 
+	// Priority submenu
+	var priorities []PaletteAction
+	for _, pr := range sdk.Priorities {
+		if needs(func(c sdk.Conversation) bool { return priorityOf(c) == pr }) {
+			priorities = append(priorities, PaletteAction{
+				Label: strings.ToUpper(pr[:1]) + pr[1:], Action: "set_priority", Priority: pr, Icon: priorityIcon(pr),
+			})
+		}
+	}
+	p.allActions = append(p.allActions, PaletteAction{Label: "Set priority…", Icon: priorityIcon("high"), Children: priorities})
+
+	// Assignment
+	assignee := func(c sdk.Conversation) int {
+		if c.Meta.Assignee == nil {
+			return 0
+		}
+		return c.Meta.Assignee.ID
+	}
+	if ctx.userID != 0 && needs(func(c sdk.Conversation) bool { return assignee(c) == ctx.userID }) {
+		p.allActions = append(p.allActions, PaletteAction{Label: "Assign to me", Action: "assign", AssigneeID: ctx.userID, Icon: "@"})
+	}
+	var agents []PaletteAction
+	for _, a := range ctx.agents {
+		if needs(func(c sdk.Conversation) bool { return assignee(c) == a.ID }) {
+			agents = append(agents, PaletteAction{
+				Label: a.Name, Action: "assign", AssigneeID: a.ID, Icon: availabilityDot(a.AvailabilityStatus),
+			})
+		}
+	}
+	if needs(func(c sdk.Conversation) bool { return assignee(c) == 0 }) {
+		agents = append(agents, PaletteAction{Label: "Unassign", Action: "assign", Icon: "○"})
+	}
+	p.allActions = append(p.allActions, PaletteAction{Label: "Assign to agent…", Icon: "@", Children: agents})
+
+	team := func(c sdk.Conversation) int {
+		if c.Meta.Team == nil {
+			return 0
+		}
+		return c.Meta.Team.ID
+	}
+	var teams []PaletteAction
+	for _, t := range ctx.teams {
+		if needs(func(c sdk.Conversation) bool { return team(c) == t.ID }) {
+			teams = append(teams, PaletteAction{Label: t.Name, Action: "assign_team", TeamID: t.ID, Icon: "◆"})
+		}
+	}
+	if needs(func(c sdk.Conversation) bool { return team(c) == 0 }) {
+		teams = append(teams, PaletteAction{Label: "Remove team", Action: "assign_team", Icon: "○"})
+	}
+	if len(teams) > 0 {
+		p.allActions = append(p.allActions, PaletteAction{Label: "Assign to team…", Icon: "◆", Children: teams})
+	}
+
+	// Labels picker: labels every target has are removed, others added
+	var labels []PaletteAction
+	for _, l := range mergeLabels(ctx.labels, targetLabels(targets)) {
+		if needs(func(c sdk.Conversation) bool { return slices.Contains(c.Labels, l) }) {
+			labels = append(labels, PaletteAction{Label: l, Action: "add_label", Tag: l, Icon: "○"})
+		} else {
+			labels = append(labels, PaletteAction{Label: l, Action: "remove_label", Tag: l, Icon: "✓"})
+		}
+	}
+	if len(labels) > 0 {
+		p.allActions = append(p.allActions, PaletteAction{Label: "Add/remove labels…", Icon: "#", Children: labels})
+	}
+
+	// Availability actions
+	for _, a := range []string{"online", "busy", "offline"} {
+		if a != ctx.availability {
+			p.allActions = append(p.allActions, PaletteAction{
+				Label: "Set availability: " + a, Action: "set_availability", Status: a, Icon: availabilityDot(a),
+			})
+		}
+	}
+
 	// App actions
+	if single {
+		p.allActions = append(p.allActions, PaletteAction{Label: "Open in browser", Action: "open_browser", Icon: "→"})
+	}
 	p.allActions = append(p.allActions,
-		PaletteAction{Label: "Open in browser", Action: "open_browser", Icon: "→"},
+		PaletteAction{Label: "Show notifications", Action: "notifications", Icon: "●"},
+		PaletteAction{Label: "Keyboard shortcuts", Action: "help", Icon: "?"},
 		PaletteAction{Label: "Refresh data", Action: "refresh", Icon: "↻"},
 		PaletteAction{Label: "Quit Chatwoot", Action: "quit", Icon: "✕"},
 	)
@@ -79,10 +208,68 @@ func (p *Palette) Open(convID int, currentStatus string) tea.Cmd {
 	return textinput.Blink
 }
 
+// targetLabels returns the labels of any target, sorted.
+func targetLabels(targets []sdk.Conversation) []string {
+	var labels []string
+	for _, conv := range targets {
+		for _, l := range conv.Labels {
+			if !slices.Contains(labels, l) {
+				labels = append(labels, l)
+			}
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+// mergeLabels returns the labels of both lists once, sorted.
+func mergeLabels(a, b []string) []string {
+	labels := slices.Clone(a)
+	for _, l := range b {
+		if !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+func priorityOf(conv sdk.Conversation) string {
+	if conv.Priority == nil {
+		return "none"
+	}
+	return *conv.Priority
+}
+
 func (p *Palette) Close() {
 	p.active = false
 }
 
+// Push opens the submenu of action.
+func (p *Palette) Push(action PaletteAction) {
+	p.stack = append(p.stack, paletteLevel{title: p.title, actions: p.allActions, cursor: p.cursor})
+	p.title = action.Label
+	p.allActions = action.Children
+	p.cursor = 0
+	p.input.SetValue("")
+	p.applyFilter()
+}
+
+// Back returns to the parent menu. It reports false at the top level.
+func (p *Palette) Back() bool {
+	if len(p.stack) == 0 {
+		return false
+	}
+	parent := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+	p.title = parent.title
+	p.allActions = parent.actions
+	p.input.SetValue("")
+	p.applyFilter()
+	p.cursor = parent.cursor
+	return true
+}
+
 func (p *Palette) IsActive() bool {
 	return p.active
 }
@@ -133,8 +320,9 @@ func (p *Palette) Selected() *PaletteAction {
 	return &p.filtered[p.cursor]
 }
 
-func (p *Palette) ConvID() int {
-	return p.convID
+// Targets returns the IDs of the conversations the palette acts on.
+func (p *Palette) Targets() []int {
+	return p.targets
 }
 
 func (p *Palette) View(termW int) string {
@@ -152,6 +340,16 @@ func (p *Palette) View(termW int) string {
 		BorderForeground(colorBorder).
 		Render(p.input.View())
 
+	if p.title != "" {
+		crumb := lipgloss.NewStyle().Foreground(colorMuted).Render("‹ ") +
+			lipgloss.NewStyle().Bold(true).Foreground(colorAccent).Render(strings.TrimSuffix(p.title, "…"))
+		header = crumb + "\n" + header
+	}
+	if len(p.targets) > 1 {
+		header = lipgloss.NewStyle().Foreground(colorMuted).
+			Render(fmt.Sprintf("%d marked conversations", len(p.targets))) + "\n" + header
+	}
+
 	var b strings.Builder
 	for i, action := range p.filtered {
 		label := "  " + action.Icon + " " + action.Label
@@ -170,7 +368,14 @@ func (p *Palette) View(termW int) string {
 		actionList = lipgloss.NewStyle().Foreground(colorMuted).Render("  No matching actions")
 	}
 
-	footer := lipgloss.NewStyle().Foreground(colorMuted).Render("↑↓ navigate  Enter select  Esc cancel")
+	back := "cancel"
+	if p.title != "" {
+		back = "back"
+	}
+	footer := lipgloss.NewStyle().Foreground(colorMuted).Render(joinHints("  ",
+		keyHint(navKeys(keys.MenuUp, keys.MenuDown), "navigate"),
+		keyHint(primaryKey(keys.Confirm), "select"),
+		keyHint(primaryKey(keys.Cancel), back)))
 
 	content := header + "\n\n" + actionList + "\n\n" + footer
 
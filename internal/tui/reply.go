@@ -116,6 +116,12 @@ func (r *ReplyEditor) Value() string {
 	return r.textarea.Value()
 }
 
+// SetValue replaces the draft, e.g. with the text from an external editor.
+func (r *ReplyEditor) SetValue(s string) {
+	r.textarea.SetValue(s)
+	r.mentionActive = false
+}
+
 func (r *ReplyEditor) IsPrivate() bool {
 	return r.private
 }
@@ -258,9 +264,15 @@ func (r *ReplyEditor) View(termW int) string {
 	if r.sending {
 		footer = lipgloss.NewStyle().Foreground(colorMuted).Render("Sending...")
 	} else {
-		hint := "Ctrl+S send  ·  Esc discard"
+		hint := joinHints("  ·  ",
+			keyHint(primaryKey(keys.Send), "send"),
+			keyHint(primaryKey(keys.ExternalEditor), "editor"),
+			keyHint(primaryKey(keys.Cancel), "discard"))
 		if r.mentionActive {
-			hint = "↑↓ select  ·  Tab/Enter pick  ·  Esc cancel"
+			hint = joinHints("  ·  ",
+				keyHint(navKeys(keys.MenuUp, keys.MenuDown), "select"),
+				keyHint(keys.Complete.Help().Key, "pick"),
+				keyHint(primaryKey(keys.Cancel), "cancel"))
 		}
 		footer = lipgloss.NewStyle().Foreground(colorMuted).Render(hint)
 	}
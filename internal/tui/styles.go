@@ -4,6 +4,7 @@ import (
 	_ "embed"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/theme"
 )
 
 // Pane widths (content width, not including borders)
@@ -12,64 +13,156 @@ const (
 	infoPaneWidth = 35
 )
 
-// Colors — adaptive for light/dark terminals
+// Colors — adaptive for light/dark terminals unless a theme replaces them
 var (
-	colorAccent    = lipgloss.AdaptiveColor{Light: "#1a73e8", Dark: "#8ab4f8"}
-	colorMuted     = lipgloss.AdaptiveColor{Light: "#666666", Dark: "#888888"}
-	colorBorder    = lipgloss.AdaptiveColor{Light: "#cccccc", Dark: "#444444"}
-	colorActiveBdr = lipgloss.AdaptiveColor{Light: "#5a9bd5", Dark: "#4a6f8a"}
-	colorSelected  = lipgloss.AdaptiveColor{Light: "#e8f0fe", Dark: "#1e3a5f"}
-	colorOutgoing  = lipgloss.AdaptiveColor{Light: "#a8c7fa", Dark: "#3d5a80"}
-	colorPrivate   = lipgloss.AdaptiveColor{Light: "#b5851e", Dark: "#8a6d3b"}
-
-	colorOpen     = lipgloss.AdaptiveColor{Light: "#0d8043", Dark: "#34a853"}
-	colorResolved = lipgloss.AdaptiveColor{Light: "#1967d2", Dark: "#669df6"}
-	colorPending  = lipgloss.AdaptiveColor{Light: "#e37400", Dark: "#fbbc04"}
-	colorSnoozed  = lipgloss.AdaptiveColor{Light: "#80868b", Dark: "#9aa0a6"}
+	colorAccent    lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#1a73e8", Dark: "#8ab4f8"}
+	colorMuted     lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#666666", Dark: "#888888"}
+	colorBorder    lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#cccccc", Dark: "#444444"}
+	colorActiveBdr lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#5a9bd5", Dark: "#4a6f8a"}
+	colorSelected  lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#e8f0fe", Dark: "#1e3a5f"}
+	colorOutgoing  lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#a8c7fa", Dark: "#3d5a80"}
+	colorPrivate   lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#b5851e", Dark: "#8a6d3b"}
+
+	colorOpen     lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#0d8043", Dark: "#34a853"}
+	colorResolved lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#1967d2", Dark: "#669df6"}
+	colorPending  lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#e37400", Dark: "#fbbc04"}
+	colorSnoozed  lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#80868b", Dark: "#9aa0a6"}
+	colorUrgent   lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#c5221f", Dark: "#f28b82"}
+
+	colorError lipgloss.TerminalColor = lipgloss.Color("#ff0000")
+	colorLogo  lipgloss.TerminalColor = lipgloss.Color("#1f93ff")
 )
 
-// Header/footer bar style — full-width bordered box
-var barStyle = lipgloss.NewStyle().
-	BorderStyle(lipgloss.RoundedBorder()).
-	BorderForeground(colorBorder).
-	Padding(0, 1)
-
-// Column styles (for body panes)
+// Styles built from the colors by buildStyles
 var (
+	// Header/footer bar style — full-width bordered box
+	barStyle lipgloss.Style
+
+	// Column styles (for body panes)
+	columnStyle       lipgloss.Style
+	activeColumnStyle lipgloss.Style
+
+	// Conversation list styles
+	convSelectedStyle lipgloss.Style
+	convSnippetStyle  lipgloss.Style
+	convMarkedStyle   lipgloss.Style
+	convChangedStyle  lipgloss.Style // changed since last opened
+	statusTabActive   lipgloss.Style
+	statusTabInactive lipgloss.Style
+	filterStyle       lipgloss.Style
+
+	spinnerStyle lipgloss.Style
+	errorStyle   lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// applyTheme replaces the colors a theme sets and rebuilds the styles.
+// Colors the theme leaves empty keep their adaptive defaults.
+func applyTheme(t *theme.Theme) {
+	if t == nil {
+		return
+	}
+	set := func(c *lipgloss.TerminalColor, v string) {
+		if v != "" {
+			*c = lipgloss.Color(v)
+		}
+	}
+	set(&colorAccent, t.Colors.Accent)
+	set(&colorMuted, t.Colors.Muted)
+	set(&colorBorder, t.Colors.Border)
+	set(&colorActiveBdr, t.Colors.ActiveBorder)
+	set(&colorSelected, t.Colors.Selected)
+	set(&colorOutgoing, t.Colors.Outgoing)
+	set(&colorPrivate, t.Colors.Private)
+	set(&colorOpen, t.Colors.Open)
+	set(&colorResolved, t.Colors.Resolved)
+	set(&colorPending, t.Colors.Pending)
+	set(&colorSnoozed, t.Colors.Snoozed)
+	set(&colorUrgent, t.Colors.Urgent)
+	set(&colorError, t.Colors.Error)
+	set(&colorLogo, t.Colors.Logo)
+	buildStyles()
+}
+
+func buildStyles() {
+	barStyle = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(0, 1)
+
 	columnStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder)
 
 	activeColumnStyle = lipgloss.NewStyle().
-				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(colorActiveBdr)
-)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(colorActiveBdr)
 
-// Conversation list styles
-var (
 	convSelectedStyle = lipgloss.NewStyle().
-				Background(colorSelected)
+		Background(colorSelected)
 
 	convSnippetStyle = lipgloss.NewStyle().
-				Foreground(colorMuted)
+		Foreground(colorMuted)
+
+	convMarkedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorAccent)
+
+	convChangedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorAccent)
 
 	statusTabActive = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorAccent).
-			Padding(0, 1).
-			Underline(true)
+		Bold(true).
+		Foreground(colorAccent).
+		Padding(0, 1).
+		Underline(true)
 
 	statusTabInactive = lipgloss.NewStyle().
-				Foreground(colorMuted).
-				Padding(0, 1)
+		Foreground(colorMuted).
+		Padding(0, 1)
 
 	filterStyle = lipgloss.NewStyle().
-			Foreground(colorAccent)
-)
+		Foreground(colorAccent)
+
+	spinnerStyle = lipgloss.NewStyle().Foreground(colorAccent)
+
+	errorStyle = lipgloss.NewStyle().Foreground(colorError)
+}
+
+// Priority icon: one cell wide so list rows stay aligned. Blank for none.
+func priorityIcon(priority string) string {
+	switch priority {
+	case "urgent":
+		return lipgloss.NewStyle().Bold(true).Foreground(colorUrgent).Render("▲")
+	case "high":
+		return lipgloss.NewStyle().Foreground(colorPending).Render("▲")
+	case "medium":
+		return lipgloss.NewStyle().Foreground(colorAccent).Render("■")
+	case "low":
+		return lipgloss.NewStyle().Foreground(colorMuted).Render("▼")
+	}
+	return " "
+}
+
+// Availability dot: online green, busy amber, offline grey
+func availabilityDot(availability string) string {
+	color := colorSnoozed
+	switch availability {
+	case "online":
+		color = colorOpen
+	case "busy":
+		color = colorPending
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("●")
+}
 
 // Status dot
 func statusDot(status string) string {
-	var color lipgloss.AdaptiveColor
+	var color lipgloss.TerminalColor
 	switch status {
 	case "open":
 		color = colorOpen
@@ -85,12 +178,6 @@ func statusDot(status string) string {
 	return lipgloss.NewStyle().Foreground(color).Render("●")
 }
 
-// Spinner style
-var spinnerStyle = lipgloss.NewStyle().Foreground(colorAccent)
-
-// Error style
-var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
-
 // Chatwoot logo (speech bubble) — loaded from logo.txt at compile time via embed
 //
 //go:embed logo.txt
@@ -1,23 +1,47 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"os"
 	"os/exec"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"github.com/chatwoot/chatwoot-cli/internal/markdown"
+	"github.com/chatwoot/chatwoot-cli/internal/notify"
 	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk/realtime"
+	"github.com/chatwoot/chatwoot-cli/internal/theme"
+	"github.com/chatwoot/chatwoot-cli/internal/timefmt"
+	"github.com/chatwoot/chatwoot-cli/internal/views"
+	"github.com/chatwoot/chatwoot-cli/internal/watch"
+	"github.com/muesli/termenv"
 )
 
+// timeFormat renders every timestamp in the TUI. Run replaces it with the
+// style and timezone from flags and config.
+var timeFormat = timefmt.Default()
+
 type Model struct {
-	client    *sdk.Client
-	accountID int
-	version   string
-	agentName string
+	client       *sdk.Client
+	accountID    int
+	version      string
+	agentName    string
+	userID       int
+	availability string // online, busy or offline; empty until the profile loads
+
+	ctx    context.Context     // cancelled when the TUI exits, ending background work
+	events chan realtime.Event // nil until the profile (and pubsub token) loads
+	live   bool                // realtime connection is up
 
 	width  int
 	height int
@@ -26,11 +50,22 @@ type Model struct {
 	msgPane        MessagePane
 	reply          ReplyEditor
 	palette        Palette
-	activePane     int // 0=conversations, 1=messages
+	notifPanel     NotificationPanel
+	help           HelpOverlay
+	unread         int // unread Chatwoot notifications
+	activePane     int // 0=conversations, 1=messages, 2=info (focus layout only)
+	layout         Layout
 	contact        *sdk.ContactFull
 	contactConvID  int // which conversation the contact was fetched for
 	agents         []sdk.AgentFull
 	teams          []sdk.TeamFull
+	labels         []sdk.AccountLabel
+	ownChanges     map[int]bool // changed by our own actions; the next refresh doesn't highlight them
+	convTracker    *watch.Tracker[sdk.Conversation]
+	bulk           *bulkState // bulk palette action in flight
+	notifier       *notify.Notifier
+	detector       *notify.Detector // reset whenever the polled filter changes
+	detectorFilter string
 	loading        bool
 	err            error
 	spinner        spinner.Model
@@ -42,15 +77,21 @@ func newModel(client *sdk.Client, accountID int, version string) Model {
 	sp.Style = spinnerStyle
 
 	return Model{
-		client:    client,
-		accountID: accountID,
-		version:   version,
-		convList:  NewConversationList(),
-		msgPane:   NewMessagePane(),
-		reply:     NewReplyEditor(),
-		palette:   NewPalette(),
-		spinner:   sp,
-		loading:   true,
+		ctx:         context.Background(),
+		client:      client,
+		accountID:   accountID,
+		version:     version,
+		convList:    NewConversationList(),
+		msgPane:     NewMessagePane(),
+		reply:       NewReplyEditor(),
+		palette:     NewPalette(),
+		notifPanel:  NewNotificationPanel(),
+		convTracker: watch.Conversations(),
+		ownChanges:  map[int]bool{},
+		detector:    notify.NewDetector(0),
+		layout:      defaultLayout(),
+		spinner:     sp,
+		loading:     true,
 	}
 }
 
@@ -60,13 +101,37 @@ func (m Model) Init() tea.Cmd {
 		fetchProfile(m.client),
 		fetchAgents(m.client),
 		fetchTeams(m.client),
+		fetchLabels(m.client),
+		fetchUnreadCount(m.client),
 		m.spinner.Tick,
 		autoRefreshTick(),
 	)
 }
 
+// fetchCmd refreshes the active tab, reloading every page loaded so far.
 func (m Model) fetchCmd() tea.Cmd {
-	return fetchConversations(m.client, m.convList.StatusFilter(), m.convList.AssigneeType(), 1)
+	return m.fetchPages(1, m.convList.Pages())
+}
+
+// fetchMoreCmd loads the active tab's next page.
+func (m Model) fetchMoreCmd() tea.Cmd {
+	next := m.convList.Pages() + 1
+	return m.fetchPages(next, next)
+}
+
+func (m Model) fetchPages(from, to int) tea.Cmd {
+	if v := m.convList.ActiveView(); v != nil {
+		return fetchView(m.client, *v, m.userID, from, to)
+	}
+	return fetchConversations(m.client, m.convList.StatusFilter(), m.convList.AssigneeType(), from, to)
+}
+
+// listFilter identifies the active tab the way conversationsMsg.filter does.
+func (m Model) listFilter() string {
+	if m.convList.ActiveView() != nil {
+		return m.convList.FilterName()
+	}
+	return m.convList.AssigneeType() + "/" + m.convList.StatusFilter()
 }
 
 // fetchContactIfNeeded returns a command to fetch the contact for the selected
@@ -92,8 +157,11 @@ func (m Model) fetchContactIfNeeded() tea.Cmd {
 // Body columns (content widths, border adds 2 each):
 // 3 columns × 2 border = 6 border cols
 // convW + msgW + infoW + 6 = width
-// convW = convPaneWidth, infoW = infoPaneWidth
-// msgW = width - convW - infoW - 6
+// convW and infoW come from the layout; hidden panes are 0 wide and
+// have no border
+// msgW = width - convW - infoW - borders
+//
+// In the focus layout only the active pane is shown, at width - 2.
 
 func (m Model) bodyHeight() int {
 	h := m.height - 8
@@ -104,18 +172,35 @@ func (m Model) bodyHeight() int {
 }
 
 func (m Model) columnWidths() (convW, msgW, infoW int) {
-	convW = convPaneWidth
-	infoW = infoPaneWidth
-	msgW = m.width - convW - infoW - 6
+	if m.focusLayout() {
+		w := m.width - 2
+		switch m.activePane {
+		case 0:
+			return w, 0, 0
+		case 1:
+			return 0, w, 0
+		}
+		return 0, 0, w
+	}
+
+	convW, infoW = m.layout.ConvWidth, m.layout.InfoWidth
+	// A hidden list comes back while it has focus
+	if m.layout.HideList && m.activePane != 0 {
+		convW = 0
+	}
+	if m.layout.HideInfo {
+		infoW = 0
+	}
+	msgW = m.width - convW - infoW - paneBorders(convW, infoW)
 
 	// If too narrow for 3 columns, drop info pane
-	if msgW < 20 {
+	if msgW < minMsgWidth && infoW > 0 {
 		infoW = 0
-		msgW = m.width - convW - 4 // 2 panes × 2 border
+		msgW = m.width - convW - paneBorders(convW, infoW)
 	}
 
 	// If still too narrow, shrink conv pane
-	if msgW < 10 {
+	if msgW < 10 && convW > 0 {
 		convW = m.width/2 - 2
 		msgW = m.width - convW - 4
 	}
@@ -123,22 +208,114 @@ func (m Model) columnWidths() (convW, msgW, infoW int) {
 	return convW, msgW, infoW
 }
 
+// paneBorders is the border width of the message pane plus the visible
+// side panes.
+func paneBorders(convW, infoW int) int {
+	n := 2
+	if convW > 0 {
+		n += 2
+	}
+	if infoW > 0 {
+		n += 2
+	}
+	return n
+}
+
+// resizePanes sizes the list and message pane. In the focus layout both
+// get the full width, so whichever is shown next is ready.
+func (m *Model) resizePanes() {
+	convW, msgW, _ := m.columnWidths()
+	if m.focusLayout() {
+		convW, msgW = m.width-2, m.width-2
+	}
+	if convW > 0 {
+		m.convList.SetSize(convW, m.bodyHeight())
+	}
+	if msgW > 0 {
+		m.msgPane.SetSize(msgW, m.bodyHeight())
+	}
+}
+
+// focusLayout reports whether panes are shown one at a time, either by
+// choice or because the terminal is too narrow for two.
+func (m Model) focusLayout() bool {
+	return m.layout.Focus || m.width < focusLayoutWidth
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		convW, msgW, _ := m.columnWidths()
-		m.convList.SetSize(convW, m.bodyHeight())
-		m.msgPane.SetSize(msgW, m.bodyHeight())
+		if m.activePane == 2 && !m.focusLayout() {
+			m.activePane = 1
+		}
+		m.resizePanes()
+		return m, nil
+
+	case layoutSavedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, nil
+
+	case editorMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		// The reply may have been sent or closed while the editor was open
+		if m.reply.IsActive() && !m.reply.IsSending() {
+			m.reply.SetValue(msg.content)
+		}
+		return m, nil
+
+	case transcriptMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, openPager(m.msgPane.Transcript(msg.messages))
+
+	case pagerMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
 		return m, nil
 
 	case profileMsg:
 		if msg.err == nil {
 			m.agentName = msg.name
+			m.userID = msg.id
+			m.availability = msg.availability
+			m.detector.SetUser(msg.id)
+			if msg.pubsubToken != "" && m.events == nil {
+				m.events = startRealtime(m.ctx, m.client, msg.pubsubToken, msg.id)
+				return m, waitForEvent(m.events)
+			}
 		}
 		return m, nil
 
+	case realtimeMsg:
+		return m.handleRealtime(msg.event)
+
+	case priorityMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			cmd := m.undoOptimistic(msg.conversationID)
+			return m, cmd
+		}
+		return m, nil
+
+	case availabilityMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.availability = msg.availability
+		return m, nil
+
 	case agentsMsg:
 		if msg.err == nil {
 			m.agents = msg.agents
@@ -151,19 +328,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case labelsMsg:
+		if msg.err == nil {
+			m.labels = msg.labels
+		}
+		return m, nil
+
 	case conversationsMsg:
 		m.loading = false
+		if msg.filter != m.listFilter() {
+			return m, nil // the tab or status changed while loading
+		}
 		if msg.err != nil {
+			if msg.more {
+				m.convList.LoadMoreFailed()
+			}
 			m.err = msg.err
 			return m, nil
 		}
 		m.err = nil
-		m.convList.SetConversations(msg.conversations)
-		return m, m.fetchContactIfNeeded()
+		if msg.counts != nil {
+			m.convList.SetCounts(msg.counts)
+		}
+		if msg.more {
+			m.convList.AppendPage(msg.conversations, msg.pages, msg.hasMore)
+			m.convTracker.Update(m.convList.conversations)
+			m.detector.Learn(msg.conversations)
+			return m, nil
+		}
+		m.convList.SetPages(msg.pages, msg.hasMore)
+		// Only rebuild the list when something changed, so an idle refresh
+		// leaves the cursor and filter untouched
+		primed := m.convTracker.Primed()
+		diff := m.convTracker.Update(msg.conversations)
+		var reload tea.Cmd
+		if !diff.Empty() {
+			m.convList.SetConversations(msg.conversations)
+			openID := m.msgPane.ConversationID()
+			for _, conv := range diff.Updated() {
+				switch {
+				case conv.ID == openID:
+					reload = refreshMessages(m.client, openID)
+				case m.ownChanges[conv.ID]:
+				case primed:
+					m.convList.MarkChanged(conv.ID)
+				}
+			}
+			m.syncMessagePane()
+		}
+		if m.bulk == nil {
+			clear(m.ownChanges)
+		}
+		notes := m.notifyPoll(msg)
+		return m, tea.Batch(m.fetchContactIfNeeded(), notes, reload)
 
 	case messagesMsg:
 		if msg.err == nil {
-			if msg.prepend {
+			if msg.refresh {
+				if m.msgPane.ConversationID() == msg.conversationID {
+					m.msgPane.MergeMessages(msg.messages)
+				}
+			} else if msg.prepend {
 				m.msgPane.PrependMessages(msg.messages)
 			} else {
 				m.msgPane.SetMessages(msg.conversationID, msg.messages)
@@ -185,9 +410,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		// Refresh conversations to reflect the status change
+		// Refresh conversations to reflect the status change. The message
+		// pane stays unless the conversation leaves the list.
 		m.loading = true
-		m.msgPane.Clear()
 		return m, tea.Batch(m.fetchCmd(), m.spinner.Tick)
 
 	case replyMsg:
@@ -204,7 +429,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil // don't auto-refresh while composing
 		}
 		m.loading = true
-		return m, tea.Batch(m.fetchCmd(), autoRefreshTick(), m.spinner.Tick)
+		return m, tea.Batch(m.fetchCmd(), fetchUnreadCount(m.client), autoRefreshTick(), m.spinner.Tick)
+
+	case notificationsMsg:
+		m.notifPanel.SetNotifications(msg.notifications, msg.err)
+		if msg.err == nil {
+			m.unread = msg.unread
+		}
+		return m, nil
+
+	case unreadCountMsg:
+		if msg.err == nil {
+			m.unread = msg.count
+		}
+		return m, nil
+
+	case notificationReadMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, fetchUnreadCount(m.client)
+
+	case openConversationMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.convList.Show(*msg.conversation)
+		m.activePane = 1
+		return m, tea.Batch(fetchMessages(m.client, msg.conversation.ID), m.fetchContactIfNeeded())
+
+	case searchDebounceMsg:
+		if query, ok := m.convList.PendingSearch(); ok && query == msg.query {
+			m.convList.SetSearching(query)
+			return m, searchServer(m.client, query)
+		}
+		return m, nil
+
+	case searchMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			msg.results = nil
+		}
+		m.convList.SetSearchResults(msg.query, msg.results)
+		return m, nil
+
+	case bulkItemMsg:
+		if m.bulk == nil {
+			return m, nil
+		}
+		m.bulk.done++
+		if msg.err != nil {
+			m.bulk.failed[msg.id] = msg.err
+		} else {
+			m.convList.Unmark(msg.id)
+		}
+		if m.bulk.done < m.bulk.total {
+			return m, nil
+		}
+		m.err = m.bulk.summary()
+		failed := slices.Collect(maps.Keys(m.bulk.failed))
+		m.bulk = nil
+		cmd := m.undoOptimistic(failed...)
+		return m, cmd
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -218,7 +506,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.palette.IsActive() {
 			return m.handlePaletteKey(msg)
 		}
+		if m.notifPanel.IsActive() {
+			return m.handleNotificationKey(msg)
+		}
+		if m.help.IsActive() {
+			return m.handleHelpKey(msg)
+		}
 		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 
 	// Forward unhandled messages to active overlay (cursor blink, etc.)
@@ -234,16 +531,132 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleRealtime applies a pushed event to the list and message pane, then
+// waits for the next one. Polling via autoRefreshTick continues as a fallback.
+func (m Model) handleRealtime(ev realtime.Event) (tea.Model, tea.Cmd) {
+	next := waitForEvent(m.events)
+
+	switch ev.Name {
+	case realtime.Connected:
+		m.live = true
+
+	case realtime.Disconnected:
+		m.live = false
+
+	case realtime.MessageCreated, realtime.MessageUpdated:
+		msg, err := ev.Message()
+		if err != nil {
+			return m, next
+		}
+		if m.msgPane.IsLoaded() && m.msgPane.ConversationID() == msg.ConversationID {
+			m.msgPane.UpsertMessage(*msg)
+		}
+		if ev.Name == realtime.MessageCreated {
+			m.convList.Touch(msg.ConversationID, msg.CreatedAt, msg.MessageType == 0)
+			if msg.ConversationID != m.msgPane.ConversationID() {
+				m.convList.MarkChanged(msg.ConversationID)
+			}
+			notes := m.notifyMessage(*msg)
+			return m, tea.Batch(next, notes)
+		}
+
+	case realtime.ConversationCreated, realtime.ConversationStatusChanged,
+		realtime.ConversationUpdated, realtime.AssigneeChanged:
+		conv, err := ev.Conversation()
+		if err != nil {
+			return m, next
+		}
+		m.convList.Upsert(*conv, m.userID)
+		if conv.ID != m.msgPane.ConversationID() && !m.ownChanges[conv.ID] && m.convList.Find(conv.ID) != nil {
+			m.convList.MarkChanged(conv.ID)
+		}
+		m.syncMessagePane()
+		return m, tea.Batch(next, m.fetchContactIfNeeded())
+
+	case realtime.NotificationCreated, realtime.NotificationUpdated, realtime.NotificationDeleted:
+		cmds := []tea.Cmd{next, fetchUnreadCount(m.client)}
+		if m.notifPanel.IsActive() {
+			cmds = append(cmds, fetchNotifications(m.client))
+		}
+		return m, tea.Batch(cmds...)
+
+	case realtime.TypingOn, realtime.TypingOff:
+		t, err := ev.Typing()
+		if err != nil || t.User == nil || t.Conversation.ID != m.msgPane.ConversationID() {
+			return m, next
+		}
+		if t.User.Type == "user" && t.User.ID == m.userID {
+			return m, next // our own typing, echoed back
+		}
+		if ev.Name == realtime.TypingOn {
+			m.msgPane.SetTyping(t.User.Name)
+		} else {
+			m.msgPane.SetTyping("")
+		}
+	}
+
+	return m, next
+}
+
+// notifyPoll sends notifications for new assignments and incoming messages
+// found by a list refresh. The first poll of each filter is the baseline.
+func (m *Model) notifyPoll(msg conversationsMsg) tea.Cmd {
+	filter := m.convList.FilterName()
+	if !m.notifier.Enabled(filter) || m.userID == 0 {
+		return nil
+	}
+	if msg.filter != m.detectorFilter {
+		m.detector.Reset()
+		m.detectorFilter = msg.filter
+	}
+	return m.sendNotifications(filter, m.detector.Update(msg.conversations))
+}
+
+// notifyMessage sends a notification for a pushed incoming message in a
+// listed conversation.
+func (m *Model) notifyMessage(msg sdk.Message) tea.Cmd {
+	filter := m.convList.FilterName()
+	conv := m.convList.Find(msg.ConversationID)
+	if conv == nil || !m.notifier.Enabled(filter) {
+		return nil
+	}
+	note, ok := m.detector.Message(*conv, msg)
+	if !ok {
+		return nil
+	}
+	return m.sendNotifications(filter, []notify.Notification{note})
+}
+
+func (m Model) sendNotifications(filter string, notes []notify.Notification) tea.Cmd {
+	if len(notes) == 0 {
+		return nil
+	}
+	n := m.notifier
+	return func() tea.Msg {
+		for _, note := range notes {
+			n.Notify(filter, note)
+		}
+		return nil
+	}
+}
+
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.convList.IsFiltering() {
 		cmd := m.convList.Update(msg)
+		// Nothing loaded matches: search the server once typing pauses
+		if query, ok := m.convList.PendingSearch(); ok {
+			cmd = tea.Batch(cmd, debounceSearch(query))
+		}
 		return m, cmd
 	}
 
 	// Global keys (work in any pane)
 	switch {
-	case matchKey(msg, keys.Quit):
+	case matchKey(msg, keys.Quit), matchKey(msg, keys.ForceQuit):
 		return m, tea.Quit
+	case matchKey(msg, keys.Help):
+		m.help.Open()
+		return m, nil
 	case matchKey(msg, keys.Refresh):
 		m.loading = true
 		return m, tea.Batch(m.fetchCmd(), m.spinner.Tick)
@@ -255,11 +668,69 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case matchKey(msg, keys.Palette):
-		if sel := m.convList.Selected(); sel != nil {
-			cmd := m.palette.Open(sel.ID, sel.Status)
+		targets := m.convList.Marked()
+		if len(targets) == 0 {
+			if sel := m.convList.Selected(); sel != nil {
+				targets = []sdk.Conversation{*sel}
+			}
+		}
+		if len(targets) > 0 {
+			labels := make([]string, len(m.labels))
+			for i, l := range m.labels {
+				labels[i] = l.Title
+			}
+			cmd := m.palette.Open(targets, paletteContext{
+				availability: m.availability,
+				userID:       m.userID,
+				agents:       m.agents,
+				teams:        m.teams,
+				labels:       labels,
+			})
 			return m, cmd
 		}
 		return m, nil
+	case matchKey(msg, keys.Notifs):
+		m.notifPanel.Open()
+		return m, fetchNotifications(m.client)
+	case matchKey(msg, keys.ShrinkList), matchKey(msg, keys.GrowList):
+		delta := paneWidthStep
+		if matchKey(msg, keys.ShrinkList) {
+			delta = -delta
+		}
+		m.layout.ResizeList(delta, m.width)
+		return m, saveLayout(m.layout)
+	case matchKey(msg, keys.ShrinkInfo), matchKey(msg, keys.GrowInfo):
+		delta := paneWidthStep
+		if matchKey(msg, keys.ShrinkInfo) {
+			delta = -delta
+		}
+		m.layout.ResizeInfo(delta, m.width)
+		return m, saveLayout(m.layout)
+	case matchKey(msg, keys.ToggleList):
+		m.layout.HideList = !m.layout.HideList
+		// Hiding the focused list moves focus to the open conversation
+		if m.layout.HideList && m.activePane == 0 && m.msgPane.IsLoaded() {
+			m.activePane = 1
+		}
+		return m, saveLayout(m.layout)
+	case matchKey(msg, keys.ToggleInfo):
+		m.layout.HideInfo = !m.layout.HideInfo
+		return m, saveLayout(m.layout)
+	case matchKey(msg, keys.FocusLayout):
+		m.layout.Focus = !m.layout.Focus
+		if m.activePane == 2 && !m.focusLayout() {
+			m.activePane = 1
+		}
+		m.resizePanes()
+		return m, saveLayout(m.layout)
+	}
+
+	// Info screen (focus layout)
+	if m.activePane == 2 {
+		if matchKey(msg, keys.Back) {
+			m.activePane = 1
+		}
+		return m, nil
 	}
 
 	// Message pane focused
@@ -268,6 +739,12 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case matchKey(msg, keys.Back):
 			m.activePane = 0
 			return m, nil
+		case matchKey(msg, keys.Select):
+			// The focus layout stacks the info pane after the messages
+			if m.focusLayout() {
+				m.activePane = 2
+			}
+			return m, nil
 		case matchKey(msg, keys.Reply), matchKey(msg, keys.Note):
 			if sel := m.convList.Selected(); sel != nil {
 				private := matchKey(msg, keys.Note)
@@ -279,17 +756,18 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 			return m, nil
-		case matchKey(msg, keys.Up):
-			m.msgPane.ScrollUp()
-			// Load more messages if scrolled near top
-			if m.msgPane.ShouldLoadMore() {
-				m.msgPane.SetLoadingMore()
-				return m, fetchMoreMessages(m.client, m.msgPane.ConversationID(), m.msgPane.OldestMessageID())
+		case matchKey(msg, keys.Pager):
+			if m.msgPane.IsLoaded() {
+				m.loading = true
+				return m, tea.Batch(fetchTranscript(m.client, m.msgPane.ConversationID()), m.spinner.Tick)
 			}
 			return m, nil
+		case matchKey(msg, keys.Up):
+			cmd := m.scrollMessages(-1)
+			return m, cmd
 		case matchKey(msg, keys.Down):
-			m.msgPane.ScrollDown()
-			return m, nil
+			cmd := m.scrollMessages(1)
+			return m, cmd
 		}
 		return m, nil
 	}
@@ -298,58 +776,130 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case matchKey(msg, keys.Tab):
 		m.convList.CycleTab()
-		m.loading = true
-		m.msgPane.Clear()
-		return m, tea.Batch(m.fetchCmd(), m.spinner.Tick)
+		cmd := m.filterChanged()
+		return m, cmd
 
 	case matchKey(msg, keys.Status):
 		m.convList.CycleStatus()
-		m.loading = true
-		m.msgPane.Clear()
-		return m, tea.Batch(m.fetchCmd(), m.spinner.Tick)
+		cmd := m.filterChanged()
+		return m, cmd
 
 	case matchKey(msg, keys.Select):
-		sel := m.convList.Selected()
-		if sel != nil {
-			m.activePane = 1
-			return m, fetchMessages(m.client, sel.ID)
+		if cmd, ok := m.openSelected(); ok {
+			return m, cmd
 		}
 
 	case matchKey(msg, keys.Filter):
 		m.convList.StartFilter()
 		return m, nil
 
+	case matchKey(msg, keys.Mark):
+		m.convList.ToggleMark()
+		return m, nil
+
+	case matchKey(msg, keys.MarkRange):
+		m.convList.MarkRange()
+		return m, nil
+
+	case matchKey(msg, keys.MarkAll):
+		m.convList.ToggleMarkAll()
+		return m, nil
+
+	case matchKey(msg, keys.Back):
+		m.convList.ClearMarks()
+		return m, nil
+
 	case matchKey(msg, keys.Up):
-		m.convList.MoveUp()
-		m.msgPane.Clear()
-		return m, m.fetchContactIfNeeded()
+		cmd := m.moveCursor(-1)
+		return m, cmd
 	case matchKey(msg, keys.Down):
-		m.convList.MoveDown()
-		m.msgPane.Clear()
-		return m, m.fetchContactIfNeeded()
+		cmd := m.moveCursor(1)
+		return m, cmd
 	}
 
 	return m, nil
 }
 
+// filterChanged reloads the list after a tab or status change.
+func (m *Model) filterChanged() tea.Cmd {
+	m.convTracker.Reset()
+	m.loading = true
+	m.msgPane.Clear()
+	return tea.Batch(m.fetchCmd(), m.spinner.Tick)
+}
+
+// openSelected loads the selected conversation's messages and focuses the
+// message pane. ok is false if nothing is selected.
+func (m *Model) openSelected() (cmd tea.Cmd, ok bool) {
+	sel := m.convList.Selected()
+	if sel == nil {
+		return nil, false
+	}
+	if m.convList.IsRemote() {
+		// Search results are partial; load the conversation and list it
+		id := sel.ID
+		m.convList.ClearFilter()
+		m.loading = true
+		return tea.Batch(fetchConversation(m.client, id), m.spinner.Tick), true
+	}
+	m.activePane = 1
+	m.convList.MarkSeen(sel.ID)
+	return fetchMessages(m.client, sel.ID), true
+}
+
+// moveCursor moves the list selection by one row up (-1) or down (1).
+func (m *Model) moveCursor(dir int) tea.Cmd {
+	if dir < 0 {
+		m.convList.MoveUp()
+		m.msgPane.Clear()
+		return m.fetchContactIfNeeded()
+	}
+	m.convList.MoveDown()
+	m.msgPane.Clear()
+	// Load the next page as the cursor nears the end of the list
+	if m.convList.ShouldLoadMore() {
+		m.convList.SetLoadingMore()
+		m.loading = true
+		return tea.Batch(m.fetchContactIfNeeded(), m.fetchMoreCmd(), m.spinner.Tick)
+	}
+	return m.fetchContactIfNeeded()
+}
+
+// scrollMessages scrolls the message pane by lines, loading older
+// messages when scrolled near the top.
+func (m *Model) scrollMessages(lines int) tea.Cmd {
+	for ; lines < 0; lines++ {
+		m.msgPane.ScrollUp()
+	}
+	for ; lines > 0; lines-- {
+		m.msgPane.ScrollDown()
+	}
+	if m.msgPane.ShouldLoadMore() {
+		m.msgPane.SetLoadingMore()
+		return fetchMoreMessages(m.client, m.msgPane.ConversationID(), m.msgPane.OldestMessageID())
+	}
+	return nil
+}
+
 func (m Model) handleReplyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.reply.IsSending() {
 		return m, nil // ignore keys while sending
 	}
 
-	key := msg.String()
-
-	switch key {
-	case "esc":
+	switch {
+	case matchKey(msg, keys.Cancel):
 		if m.reply.MentionActive() {
 			m.reply.CloseMention()
 			return m, nil
 		}
 		m.reply.Close()
 		return m, nil
-	case "ctrl+c":
+	case matchKey(msg, keys.ForceQuit):
 		return m, tea.Quit
-	case "ctrl+s":
+	case matchKey(msg, keys.ExternalEditor):
+		m.reply.CloseMention()
+		return m, editDraft(m.reply.Value())
+	case matchKey(msg, keys.Send):
 		content := strings.TrimSpace(m.reply.Value())
 		if content == "" {
 			m.reply.Close()
@@ -361,14 +911,14 @@ func (m Model) handleReplyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// When mention picker is active, intercept navigation keys
 	if m.reply.MentionActive() {
-		switch key {
-		case "up":
+		switch {
+		case matchKey(msg, keys.MenuUp):
 			m.reply.MentionUp()
 			return m, nil
-		case "down":
+		case matchKey(msg, keys.MenuDown):
 			m.reply.MentionDown()
 			return m, nil
-		case "enter", "tab":
+		case matchKey(msg, keys.Complete):
 			m.reply.CompleteMention()
 			return m, nil
 		}
@@ -378,8 +928,8 @@ func (m Model) handleReplyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
-	// Check if @ triggers a mention
-	if key == "@" && m.reply.HasMentions() {
+	// Check if @ triggers a mention. This is typed text, not a binding.
+	if msg.String() == "@" && m.reply.HasMentions() {
 		val := m.reply.Value()
 		if len(val) == 0 || val[len(val)-1] == ' ' || val[len(val)-1] == '\n' {
 			cmd := m.reply.Update(msg)
@@ -394,28 +944,50 @@ func (m Model) handleReplyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.palette.Close()
+	switch {
+	case matchKey(msg, keys.Cancel):
+		if !m.palette.Back() {
+			m.palette.Close()
+		}
 		return m, nil
-	case "ctrl+c":
+	case matchKey(msg, keys.ForceQuit):
 		return m, tea.Quit
-	case "up":
+	case matchKey(msg, keys.MenuUp):
 		m.palette.MoveUp()
 		return m, nil
-	case "down":
+	case matchKey(msg, keys.MenuDown):
 		m.palette.MoveDown()
 		return m, nil
-	case "enter":
+	case matchKey(msg, keys.Confirm):
 		action := m.palette.Selected()
 		if action == nil {
 			m.palette.Close()
 			return m, nil
 		}
+		if len(action.Children) > 0 {
+			m.palette.Push(*action)
+			return m, nil
+		}
 		m.palette.Close()
+		targets := m.palette.Targets()
+		op, ok := bulkOpFor(*action)
+		// Results of a second run would count against the first one's total
+		if ok && m.bulk != nil {
+			m.err = fmt.Errorf("%s: wait for it to finish", m.bulk.progress())
+			return m, nil
+		}
+		if ok && (len(targets) > 1 || !singleAction(action.Action)) {
+			m.applyOptimistic(*action, targets)
+			cmd := m.startBulk(op, targets)
+			return m, cmd
+		}
 		switch action.Action {
 		case "toggle_status":
-			return m, toggleStatus(m.client, m.palette.ConvID(), action.Status, action.SnoozedUntil)
+			m.ownChanges[targets[0]] = true
+			return m, toggleStatus(m.client, targets[0], action.Status, action.SnoozedUntil)
+		case "set_priority":
+			m.applyOptimistic(*action, targets)
+			return m, setPriority(m.client, targets[0], action.Priority)
 		case "open_browser":
 			if sel := m.convList.Selected(); sel != nil {
 				url := fmt.Sprintf("%s/app/accounts/%d/conversations/%d",
@@ -423,6 +995,14 @@ func (m Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				openBrowser(url)
 			}
 			return m, nil
+		case "set_availability":
+			return m, setAvailability(m.client, action.Status)
+		case "notifications":
+			m.notifPanel.Open()
+			return m, fetchNotifications(m.client)
+		case "help":
+			m.help.Open()
+			return m, nil
 		case "refresh":
 			m.loading = true
 			return m, tea.Batch(m.fetchCmd(), m.spinner.Tick)
@@ -437,6 +1017,159 @@ func (m Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleNotificationKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case matchKey(msg, keys.ForceQuit):
+		return m, tea.Quit
+	case matchKey(msg, keys.Cancel), matchKey(msg, keys.Notifs):
+		m.notifPanel.Close()
+		return m, nil
+	case matchKey(msg, keys.Up):
+		m.notifPanel.MoveUp()
+		return m, nil
+	case matchKey(msg, keys.Down):
+		m.notifPanel.MoveDown()
+		return m, nil
+	case matchKey(msg, keys.ReadAll):
+		m.notifPanel.MarkAllRead()
+		m.unread = 0
+		return m, markAllNotificationsRead(m.client)
+	case matchKey(msg, keys.Confirm):
+		n := m.notifPanel.Selected()
+		if n == nil {
+			return m, nil
+		}
+		var cmds []tea.Cmd
+		if !n.IsRead() {
+			m.notifPanel.MarkRead(n.ID)
+			m.unread = max(0, m.unread-1)
+			cmds = append(cmds, markNotificationRead(m.client, n.ID))
+		}
+		if convID := n.ConversationID(); convID != 0 {
+			m.notifPanel.Close()
+			cmds = append(cmds, m.openConversation(convID))
+		}
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+func (m Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case matchKey(msg, keys.ForceQuit):
+		return m, tea.Quit
+	case matchKey(msg, keys.Cancel), matchKey(msg, keys.Help):
+		m.help.Close()
+	case matchKey(msg, keys.Up):
+		m.help.ScrollUp()
+	case matchKey(msg, keys.Down):
+		m.help.ScrollDown(m.height)
+	}
+	return m, nil
+}
+
+// singleAction reports whether a palette action has its own command for a
+// single conversation; everything else goes through the bulk runner.
+func singleAction(action string) bool {
+	return action == "toggle_status" || action == "set_priority"
+}
+
+// applyOptimistic shows the result of a palette action on the listed
+// conversations before the API confirms it. Failures are undone by
+// undoOptimistic.
+func (m *Model) applyOptimistic(action PaletteAction, ids []int) {
+	var fn func(*sdk.Conversation)
+	switch action.Action {
+	case "set_priority":
+		for _, id := range ids {
+			m.convList.SetPriority(id, action.Priority)
+		}
+	case "assign":
+		var agent *sdk.Agent
+		if action.AssigneeID != 0 {
+			agent = &sdk.Agent{ID: action.AssigneeID, Name: m.agentNameByID(action.AssigneeID)}
+		}
+		fn = func(c *sdk.Conversation) { c.Meta.Assignee = agent }
+	case "assign_team":
+		var team *sdk.Team
+		if action.TeamID != 0 {
+			team = &sdk.Team{ID: action.TeamID, Name: action.Label}
+		}
+		fn = func(c *sdk.Conversation) { c.Meta.Team = team }
+	case "add_label":
+		fn = func(c *sdk.Conversation) {
+			if !slices.Contains(c.Labels, action.Tag) {
+				c.Labels = append(slices.Clone(c.Labels), action.Tag)
+			}
+		}
+	case "remove_label":
+		fn = func(c *sdk.Conversation) {
+			c.Labels = slices.DeleteFunc(slices.Clone(c.Labels), func(l string) bool { return l == action.Tag })
+		}
+	}
+	for _, id := range ids {
+		if fn != nil {
+			m.convList.Modify(id, fn)
+		}
+		m.ownChanges[id] = true
+	}
+}
+
+// undoOptimistic reloads the list after an action failed on ids. The
+// server still has what the tracker saw last, so the tracker forgets them
+// to make the refresh replace the optimistic edits.
+func (m *Model) undoOptimistic(ids ...int) tea.Cmd {
+	m.convTracker.Forget(ids...)
+	for _, id := range ids {
+		m.ownChanges[id] = true
+	}
+	m.loading = true
+	return tea.Batch(m.fetchCmd(), m.spinner.Tick)
+}
+
+func (m Model) agentNameByID(id int) string {
+	for _, a := range m.agents {
+		if a.ID == id {
+			return a.Name
+		}
+	}
+	if id == m.userID {
+		return m.agentName
+	}
+	return ""
+}
+
+// startBulk applies op to the conversations, showing progress in the header.
+func (m *Model) startBulk(op bulkOp, ids []int) tea.Cmd {
+	m.bulk = &bulkState{label: op.label, total: len(ids), failed: map[int]error{}}
+	m.err = nil
+	return tea.Batch(runBulk(m.client, op, ids), m.spinner.Tick)
+}
+
+// syncMessagePane clears the message pane when its conversation is no
+// longer the selected one, e.g. after it left the list on a refresh.
+func (m *Model) syncMessagePane() {
+	if !m.msgPane.IsLoaded() {
+		return
+	}
+	if sel := m.convList.Selected(); sel == nil || sel.ID != m.msgPane.ConversationID() {
+		m.msgPane.Clear()
+		m.activePane = 0
+	}
+}
+
+// openConversation selects a conversation and loads its messages, fetching
+// it first if it isn't in the current list.
+func (m *Model) openConversation(convID int) tea.Cmd {
+	m.convList.MarkSeen(convID)
+	if !m.convList.Focus(convID) {
+		return fetchConversation(m.client, convID)
+	}
+	m.activePane = 1
+	m.msgPane.Clear()
+	return tea.Batch(fetchMessages(m.client, convID), m.fetchContactIfNeeded())
+}
+
 func (m Model) View() string {
 	if m.width == 0 {
 		return "Loading..."
@@ -454,12 +1187,25 @@ func (m Model) View() string {
 	leftInfo := fmt.Sprintf("%s  |  Account: %d", host, m.accountID)
 	if m.agentName != "" {
 		leftInfo += "  |  " + m.agentName
+		if m.availability != "" {
+			leftInfo += " " + availabilityDot(m.availability) + " " + m.availability
+		}
+	}
+	if m.live {
+		leftInfo += "  |  " + lipgloss.NewStyle().Foreground(colorOpen).Render("●") + " live"
+	}
+	if m.unread > 0 {
+		leftInfo += "  |  " + lipgloss.NewStyle().Bold(true).Foreground(colorAccent).
+			Render(fmt.Sprintf("%d unread", m.unread)) + " (n)"
 	}
 
 	rightInfo := m.version
 	if m.loading {
 		rightInfo = m.spinner.View() + " refreshing..."
 	}
+	if m.bulk != nil {
+		rightInfo = m.spinner.View() + " " + m.bulk.progress()
+	}
 	if m.err != nil {
 		rightInfo = errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
 	}
@@ -477,35 +1223,41 @@ func (m Model) View() string {
 	convW, msgW, infoW := m.columnWidths()
 	bodyH := m.bodyHeight()
 
+	var cols []string
+
 	// Conversation list column
-	m.convList.SetSize(convW, bodyH)
-	convContent := m.convList.View()
-	convStyle := columnStyle
-	if m.activePane == 0 {
-		convStyle = activeColumnStyle
+	if convW > 0 {
+		m.convList.SetSize(convW, bodyH)
+		convStyle := columnStyle
+		if m.activePane == 0 {
+			convStyle = activeColumnStyle
+		}
+		cols = append(cols, convStyle.Width(convW).Height(bodyH).Render(m.convList.View()))
 	}
-	convCol := convStyle.Width(convW).Height(bodyH).Render(convContent)
 
 	// Messages column
-	m.msgPane.SetSize(msgW, bodyH)
-	msgContent := m.msgPane.View()
-	msgStyle := columnStyle
-	if m.activePane == 1 {
-		msgStyle = activeColumnStyle
+	if msgW > 0 {
+		m.msgPane.SetSize(msgW, bodyH)
+		msgStyle := columnStyle
+		if m.activePane == 1 {
+			msgStyle = activeColumnStyle
+		}
+		cols = append(cols, msgStyle.Width(msgW).Height(bodyH).Render(m.msgPane.View()))
 	}
-	msgCol := msgStyle.Width(msgW).Height(bodyH).Render(msgContent)
 
-	var body string
+	// Info column
 	if infoW > 0 {
-		infoContent := m.renderInfo(infoW, bodyH)
-		infoCol := columnStyle.Width(infoW).Height(bodyH).Render(infoContent)
-		body = lipgloss.JoinHorizontal(lipgloss.Top, convCol, msgCol, infoCol)
-	} else {
-		body = lipgloss.JoinHorizontal(lipgloss.Top, convCol, msgCol)
+		infoStyle := columnStyle
+		if m.activePane == 2 {
+			infoStyle = activeColumnStyle
+		}
+		cols = append(cols, infoStyle.Width(infoW).Height(bodyH).Render(m.renderInfo(infoW, bodyH)))
 	}
 
+	body := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+
 	// === Footer ===
-	footer := barStyle.Width(barContentW).Render(helpText(m.convList.Selected() != nil))
+	footer := barStyle.Width(barContentW).Render(helpText(m.convList.Selected() != nil, m.convList.MarkedCount()))
 
 	view := header + "\n" + body + "\n" + footer
 
@@ -517,6 +1269,14 @@ func (m Model) View() string {
 		return overlayCenter(view, m.palette.View(m.width), m.width, m.height)
 	}
 
+	if m.notifPanel.IsActive() {
+		return overlayCenter(view, m.notifPanel.View(m.width, m.height), m.width, m.height)
+	}
+
+	if m.help.IsActive() {
+		return m.help.View(m.width, m.height)
+	}
+
 	return view
 }
 
@@ -561,8 +1321,8 @@ func (m Model) renderInfo(w, h int) string {
 	b.WriteString(labelStyle.Render("Status:   ") + statusDot(conv.Status) + " " + conv.Status + "\n")
 	b.WriteString(labelStyle.Render("Channel:  ") + conv.Meta.Channel + "\n")
 	b.WriteString(labelStyle.Render("Messages: ") + fmt.Sprintf("%d", conv.MessagesCount) + "\n")
-	b.WriteString(labelStyle.Render("Created:  ") + formatTime(conv.CreatedAt) + "\n")
-	b.WriteString(labelStyle.Render("Activity: ") + formatTime(conv.LastActivityAt) + "\n")
+	b.WriteString(labelStyle.Render("Created:  ") + timeFormat.Format(conv.CreatedAt) + "\n")
+	b.WriteString(labelStyle.Render("Activity: ") + timeFormat.Format(conv.LastActivityAt) + "\n")
 
 	b.WriteString("\n")
 
@@ -700,10 +1460,76 @@ func overlayCenter(bg, fg string, w, h int) string {
 	return strings.Join(bgLines, "\n")
 }
 
+// termOutput is the terminal shared by bubbletea and the notifier. Writes
+// are serialized and bubbletea writes each frame at once, so notification
+// escapes from background commands land between frames, not inside one.
+// Embedding the file keeps it recognizable as a terminal.
+type termOutput struct {
+	*os.File
+	mu sync.Mutex
+}
+
+func (o *termOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.File.Write(p)
+}
+
+func (o *termOutput) WriteString(s string) (int, error) {
+	return o.Write([]byte(s))
+}
+
 // Run launches the TUI with the given SDK client.
-func Run(client *sdk.Client, accountID int, version string) error {
-	m := newModel(client, accountID, version)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+func Run(client *sdk.Client, cfg *config.Config, tf *timefmt.Formatter, version string) error {
+	timeFormat = tf
+
+	out := &termOutput{File: os.Stdout}
+	notifier, err := notify.New(cfg.Notifications, out, timeFormat.Location)
+	if err != nil {
+		return err
+	}
+
+	saved, err := views.Load()
+	if err != nil {
+		return err
+	}
+
+	km, err := loadKeyMap()
+	if err != nil {
+		return err
+	}
+	keys = km
+
+	th, err := theme.Load(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	applyTheme(th)
+	mdStyle, err := th.MarkdownStyle(termenv.HasDarkBackground())
+	if err != nil {
+		return err
+	}
+	chatStyle = markdown.ChatStyle(mdStyle)
+
+	layout, err := loadLayout()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newModel(client, cfg.AccountID, version)
+	m.ctx = ctx
+	m.layout = layout
+	m.notifier = notifier
+	m.convList.SetViews(saved)
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithOutput(out)}
+	if cfg.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+		hyperlinks = true
+	}
+	p := tea.NewProgram(m, opts...)
+	_, err = p.Run()
 	return err
 }
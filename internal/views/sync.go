@@ -0,0 +1,81 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/query"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// Skipped is a remote filter Pull left out, and why.
+type Skipped struct {
+	Name   string
+	Reason string
+}
+
+// Pull adds Chatwoot's saved conversation filters that aren't linked to a
+// local view yet. Filters whose names are already used locally, or whose
+// conditions the query language can't express, are skipped and reported.
+func Pull(client *sdk.Client, views []View) (updated []View, added []string, skipped []Skipped, err error) {
+	remote, err := client.CustomFilters().List("conversation")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch saved filters: %w", err)
+	}
+
+	linked := map[int]bool{}
+	names := map[string]bool{}
+	for _, v := range views {
+		linked[v.RemoteID] = true
+		names[v.Name] = true
+	}
+
+	for _, cf := range remote {
+		if linked[cf.ID] {
+			continue
+		}
+		if names[cf.Name] {
+			skipped = append(skipped, Skipped{cf.Name, "a local view has that name"})
+			continue
+		}
+		v := View{
+			Name:     cf.Name,
+			Status:   "all", // the saved query carries its own status condition
+			Query:    query.Format(cf.Query.Payload),
+			RemoteID: cf.ID,
+		}
+		// Load rejects the whole file over one invalid view
+		if _, err := query.Parse(v.Query, time.Now()); err != nil {
+			skipped = append(skipped, Skipped{cf.Name, "can't express its conditions: " + err.Error()})
+			continue
+		}
+		views = append(views, v)
+		added = append(added, cf.Name)
+	}
+	return views, added, skipped, nil
+}
+
+// Push saves local views that aren't linked to a Chatwoot filter yet.
+// Relative ages in queries are saved as the dates they resolve to today.
+func Push(client *sdk.Client, views []View, userID int) (pushed []string, err error) {
+	for i := range views {
+		v := &views[i]
+		if v.RemoteID != 0 {
+			continue
+		}
+		f, err := v.Filter(time.Now(), userID)
+		if err != nil {
+			return pushed, err
+		}
+		if f.Len() == 0 {
+			continue // nothing to filter on; Chatwoot rejects empty filters
+		}
+		cf, err := client.CustomFilters().Create(v.Name, f)
+		if err != nil {
+			return pushed, err
+		}
+		v.RemoteID = cf.ID
+		pushed = append(pushed, v.Name)
+	}
+	return pushed, nil
+}
@@ -0,0 +1,335 @@
+// Package views stores named conversation queues ("saved views") in
+// ~/.chatwoot/views.yaml. The CLI uses them with `conversation list --view`
+// and the TUI shows each one as an extra tab. Views can be synced with
+// Chatwoot's custom filters so they also appear in the web app.
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/config"
+	"github.com/chatwoot/chatwoot-cli/internal/query"
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// View bundles the filters of one conversation queue. Views without a
+// Query use the list endpoint, which supports sorting; views with one use
+// the filter API, combining Query with the other fields.
+type View struct {
+	Name     string   `yaml:"name"`
+	Status   string   `yaml:"status,omitempty"`   // default: open
+	Assignee string   `yaml:"assignee,omitempty"` // me, unassigned, all (default)
+	Inbox    int      `yaml:"inbox,omitempty"`
+	Team     int      `yaml:"team,omitempty"`
+	Labels   []string `yaml:"labels,omitempty"`
+	Priority []string `yaml:"priority,omitempty"` // urgent, high, medium, low, none
+	Sort     string   `yaml:"sort,omitempty"`     // latest (default), created_at, priority
+	Query    string   `yaml:"query,omitempty"`    // filter query, see internal/query
+
+	RemoteID int `yaml:"remote_id,omitempty"` // Chatwoot custom filter ID once synced
+}
+
+type file struct {
+	Views []View `yaml:"views"`
+}
+
+func Path() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "views.yaml"), nil
+}
+
+// Load returns the saved views, or none if the file doesn't exist.
+func Load() ([]View, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse views: %w", err)
+	}
+	for _, v := range f.Views {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid view in %s: %w", path, err)
+		}
+	}
+	return f.Views, nil
+}
+
+func Save(views []View) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(file{Views: views})
+	if err != nil {
+		return fmt.Errorf("failed to encode views: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write views: %w", err)
+	}
+	return nil
+}
+
+// Find returns the view with the given name.
+func Find(views []View, name string) (*View, error) {
+	for i := range views {
+		if views[i].Name == name {
+			return &views[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no view named %q (see `chatwoot view list`)", name)
+}
+
+// Validate checks field values, including that Query parses.
+func (v View) Validate() error {
+	if v.Name == "" {
+		return fmt.Errorf("view has no name")
+	}
+	if v.Assignee != "" && !slices.Contains([]string{"me", "unassigned", "all"}, v.Assignee) {
+		return fmt.Errorf("view %q: assignee must be me, unassigned or all", v.Name)
+	}
+	for _, p := range v.Priority {
+		if !slices.Contains(sdk.Priorities, p) {
+			return fmt.Errorf("view %q: unknown priority %q", v.Name, p)
+		}
+	}
+	if v.Query != "" {
+		if _, err := query.Parse(v.Query, time.Now()); err != nil {
+			return fmt.Errorf("view %q: %w", v.Name, err)
+		}
+	}
+	return nil
+}
+
+func (v View) StatusOrDefault() string {
+	if v.Status == "" {
+		return "open"
+	}
+	return v.Status
+}
+
+func (v View) AssigneeOrDefault() string {
+	if v.Assignee == "" {
+		return "all"
+	}
+	return v.Assignee
+}
+
+// ListOptions returns the list endpoint options for views without a Query.
+func (v View) ListOptions(page int) sdk.ListOptions {
+	sort := v.Sort
+	switch sort {
+	case "", "latest":
+		sort = "last_activity_at_desc"
+	case "created_at":
+		sort = "created_at_desc"
+	case "priority":
+		sort = "priority_desc"
+	}
+	return sdk.ListOptions{
+		Status:       v.StatusOrDefault(),
+		InboxID:      v.Inbox,
+		AssigneeType: v.AssigneeOrDefault(),
+		TeamID:       v.Team,
+		Labels:       v.Labels,
+		SortBy:       sort,
+		Page:         page,
+	}
+}
+
+// Filter converts the whole view to filter API predicates. userID stands
+// in for "me" in the assignee field and in assignee=me conditions.
+func (v View) Filter(now time.Time, userID int) (*sdk.Filter, error) {
+	f := sdk.NewFilter()
+	if v.StatusOrDefault() != "all" {
+		f.And("status", sdk.OpEqual, v.StatusOrDefault())
+	}
+	switch v.AssigneeOrDefault() {
+	case "me":
+		f.And("assignee_id", sdk.OpEqual, strconv.Itoa(userID))
+	case "unassigned":
+		f.And("assignee_id", sdk.OpNotPresent)
+	}
+	if v.Inbox != 0 {
+		f.And("inbox_id", sdk.OpEqual, strconv.Itoa(v.Inbox))
+	}
+	if v.Team != 0 {
+		f.And("team_id", sdk.OpEqual, strconv.Itoa(v.Team))
+	}
+	if len(v.Labels) > 0 {
+		f.And("labels", sdk.OpEqual, v.Labels...)
+	}
+	// The filter API can't combine "none" with other priorities, so "none"
+	// only counts on its own
+	if priorities := withoutNone(v.Priority); len(priorities) > 0 {
+		f.And("priority", sdk.OpEqual, priorities...)
+	} else if len(v.Priority) > 0 {
+		f.And("priority", sdk.OpNotPresent)
+	}
+
+	if v.Query != "" {
+		q, err := query.Parse(v.Query, now)
+		if err != nil {
+			return nil, fmt.Errorf("view %q: %w", v.Name, err)
+		}
+		// Conditions from the query keep their own and/or joins. As in the
+		// filter API, "or" is not grouped: it splits the whole view.
+		join := "and"
+		for _, p := range q.Predicates() {
+			if p.AttributeKey == "assignee_id" {
+				for i, val := range p.Values {
+					if val == "me" {
+						p.Values[i] = strconv.Itoa(userID)
+					}
+				}
+			}
+			if join == "or" {
+				f.Or(p.AttributeKey, p.FilterOperator, p.Values...)
+			} else {
+				f.And(p.AttributeKey, p.FilterOperator, p.Values...)
+			}
+			join = "and"
+			if p.QueryOperator != nil {
+				join = *p.QueryOperator
+			}
+		}
+	}
+	return f, nil
+}
+
+// NeedsUser reports whether running the view requires the current agent's
+// ID, i.e. it uses the filter API and refers to "me".
+func (v View) NeedsUser() bool {
+	if v.Query == "" {
+		return false // the list endpoint resolves assignee=me itself
+	}
+	return v.AssigneeOrDefault() == "me" || containsMe(v.Query)
+}
+
+// Fetch returns one page of the view's conversations. more reports whether
+// the server returned any before local filtering, so callers can tell the
+// end of the list from a page the priority filter emptied.
+func Fetch(client *sdk.Client, v View, page, userID int) (convs []sdk.Conversation, more bool, err error) {
+	if v.Query != "" {
+		f, err := v.Filter(time.Now(), userID)
+		if err != nil {
+			return nil, false, err
+		}
+		resp, err := client.Conversations().Filter(f, page)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Payload, len(resp.Payload) > 0, nil
+	}
+
+	resp, err := client.Conversations().List(v.ListOptions(page))
+	if err != nil {
+		return nil, false, err
+	}
+	convs = resp.Data.Payload
+	more = len(convs) > 0
+
+	// The list endpoint has no priority filter
+	if len(v.Priority) > 0 {
+		kept := convs[:0]
+		for _, conv := range convs {
+			if slices.Contains(v.Priority, priorityOf(conv)) {
+				kept = append(kept, conv)
+			}
+		}
+		convs = kept
+	}
+	return convs, more, nil
+}
+
+// Matches reports whether conv belongs in the view. Views with a Query
+// can't be evaluated locally and never match.
+func (v View) Matches(conv sdk.Conversation, userID int) bool {
+	if v.Query != "" {
+		return false
+	}
+	if status := v.StatusOrDefault(); status != "all" && conv.Status != status {
+		return false
+	}
+	switch v.AssigneeOrDefault() {
+	case "me":
+		if conv.Meta.Assignee == nil || conv.Meta.Assignee.ID != userID {
+			return false
+		}
+	case "unassigned":
+		if conv.Meta.Assignee != nil {
+			return false
+		}
+	}
+	if v.Inbox != 0 && conv.InboxID != v.Inbox {
+		return false
+	}
+	if v.Team != 0 && (conv.Meta.Team == nil || conv.Meta.Team.ID != v.Team) {
+		return false
+	}
+	if len(v.Labels) > 0 && !slices.ContainsFunc(v.Labels, func(l string) bool {
+		return slices.Contains(conv.Labels, l)
+	}) {
+		return false // like the API, any one of the labels matches
+	}
+	if len(v.Priority) > 0 && !slices.Contains(v.Priority, priorityOf(conv)) {
+		return false
+	}
+	return true
+}
+
+func priorityOf(conv sdk.Conversation) string {
+	if conv.Priority == nil {
+		return "none"
+	}
+	return *conv.Priority
+}
+
+func withoutNone(priorities []string) []string {
+	var out []string
+	for _, p := range priorities {
+		if p != "none" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func containsMe(q string) bool {
+	if q == "" {
+		return false
+	}
+	f, err := query.Parse(q, time.Now())
+	if err != nil {
+		return false
+	}
+	for _, p := range f.Predicates() {
+		if p.AttributeKey == "assignee_id" && slices.Contains(p.Values, "me") {
+			return true
+		}
+	}
+	return false
+}
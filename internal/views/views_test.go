@@ -0,0 +1,172 @@
+package views
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		view View
+		want []string // attribute operator values join
+	}{
+		{"default", View{Name: "v"}, []string{
+			"status " + sdk.OpEqual + " open",
+		}},
+		{"mine in an inbox", View{Name: "v", Status: "all", Assignee: "me", Inbox: 3}, []string{
+			"assignee_id " + sdk.OpEqual + " 7 and",
+			"inbox_id " + sdk.OpEqual + " 3",
+		}},
+		{"priorities without none", View{Name: "v", Status: "all", Priority: []string{"urgent", "none", "high"}}, []string{
+			"priority " + sdk.OpEqual + " urgent,high",
+		}},
+		{"only none", View{Name: "v", Status: "all", Priority: []string{"none"}}, []string{
+			"priority " + sdk.OpNotPresent + " ",
+		}},
+		{"query with or", View{Name: "v", Status: "all", Query: "assignee=me or team=2"}, []string{
+			"assignee_id " + sdk.OpEqual + " 7 or",
+			"team_id " + sdk.OpEqual + " 2",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.view.Filter(time.Now(), 7)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range f.Predicates() {
+				s := p.AttributeKey + " " + p.FilterOperator + " " + strings.Join(p.Values, ",")
+				if p.QueryOperator != nil {
+					s += " " + *p.QueryOperator
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	high := "high"
+	conv := sdk.Conversation{
+		Status:   "open",
+		InboxID:  3,
+		Priority: &high,
+		Labels:   []string{"billing"},
+		Meta:     sdk.ConversationMeta{Assignee: &sdk.Agent{ID: 7}},
+	}
+
+	tests := []struct {
+		name string
+		view View
+		want bool
+	}{
+		{"default", View{Name: "v"}, true},
+		{"other status", View{Name: "v", Status: "resolved"}, false},
+		{"mine", View{Name: "v", Assignee: "me"}, true},
+		{"unassigned", View{Name: "v", Assignee: "unassigned"}, false},
+		{"other inbox", View{Name: "v", Inbox: 4}, false},
+		{"any label", View{Name: "v", Labels: []string{"sales", "billing"}}, true},
+		{"no team", View{Name: "v", Team: 2}, false},
+		{"priority", View{Name: "v", Priority: []string{"urgent", "high"}}, true},
+		{"priority none", View{Name: "v", Priority: []string{"none"}}, false},
+		{"query", View{Name: "v", Query: "inbox=3"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.view.Matches(conv, 7); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchMore(t *testing.T) {
+	low, urgent := "low", "urgent"
+	pages := map[string][]sdk.Conversation{
+		"1": {{ID: 1, Priority: &low}, {ID: 2, Priority: &urgent}},
+		"2": {{ID: 3, Priority: &low}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp sdk.ConversationsListResponse
+		resp.Data.Payload = pages[r.URL.Query().Get("page")]
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	client := sdk.NewClient(srv.URL, "", 1)
+	view := View{Name: "v", Priority: []string{"urgent"}}
+
+	tests := []struct {
+		page     int
+		wantIDs  []int
+		wantMore bool
+	}{
+		{1, []int{2}, true},
+		{2, nil, true}, // emptied by the priority filter, not the end
+		{3, nil, false},
+	}
+	for _, tt := range tests {
+		convs, more, err := Fetch(client, view, tt.page, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int
+		for _, c := range convs {
+			ids = append(ids, c.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.wantIDs) || more != tt.wantMore {
+			t.Errorf("page %d: got %v more=%v, want %v more=%v", tt.page, ids, more, tt.wantIDs, tt.wantMore)
+		}
+	}
+}
+
+func TestPull(t *testing.T) {
+	pred := func(attr, op string, values ...string) sdk.FilterPredicate {
+		return sdk.FilterPredicate{AttributeKey: attr, FilterOperator: op, Values: values}
+	}
+	remote := []sdk.CustomFilter{
+		{ID: 1, Name: "linked", Query: sdk.CustomFilterQuery{Payload: []sdk.FilterPredicate{pred("status", sdk.OpEqual, "open")}}},
+		{ID: 2, Name: "mine", Query: sdk.CustomFilterQuery{Payload: []sdk.FilterPredicate{pred("status", sdk.OpEqual, "open")}}},
+		{ID: 3, Name: "vip", Query: sdk.CustomFilterQuery{Payload: []sdk.FilterPredicate{pred("labels", sdk.OpEqual, "vip", "gold")}}},
+		{ID: 4, Name: "no values", Query: sdk.CustomFilterQuery{Payload: []sdk.FilterPredicate{pred("labels", sdk.OpEqual)}}},
+		{ID: 5, Name: "odd key", Query: sdk.CustomFilterQuery{Payload: []sdk.FilterPredicate{pred("bad key", sdk.OpEqual, "x")}}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(remote)
+	}))
+	defer srv.Close()
+
+	local := []View{{Name: "linked", RemoteID: 1}, {Name: "mine"}}
+	all, added, skipped, err := Pull(sdk.NewClient(srv.URL, "", 1), local)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"vip", "no values"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added %q, want %q", added, want)
+	}
+	var names []string
+	for _, s := range skipped {
+		names = append(names, s.Name)
+	}
+	if want := []string{"mine", "odd key"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("skipped %q, want %q", names, want)
+	}
+	// Every pulled view must survive Load's validation
+	for _, v := range all {
+		if err := v.Validate(); err != nil {
+			t.Errorf("pulled invalid view: %v", err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package watch detects which records changed between successive polls.
+// The TUI uses it on every auto-refresh and list commands use it for --watch.
+package watch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+// Diff is the result of comparing one poll with the previous one.
+type Diff[T any] struct {
+	Added   []T
+	Changed []T
+	Removed []int // IDs present last time but missing now
+}
+
+// Empty reports whether nothing was added, changed or removed.
+func (d Diff[T]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Updated returns added and changed records together, in poll order.
+func (d Diff[T]) Updated() []T {
+	return append(append([]T{}, d.Added...), d.Changed...)
+}
+
+// Tracker remembers a fingerprint per record ID between polls.
+type Tracker[T any] struct {
+	id          func(T) int
+	fingerprint func(T) string
+	seen        map[int]string
+}
+
+func NewTracker[T any](id func(T) int, fingerprint func(T) string) *Tracker[T] {
+	return &Tracker[T]{id: id, fingerprint: fingerprint}
+}
+
+// Primed reports whether Update has been called since creation or Reset.
+func (t *Tracker[T]) Primed() bool {
+	return t.seen != nil
+}
+
+// Reset forgets all records, e.g. when the polled query changes.
+func (t *Tracker[T]) Reset() {
+	t.seen = nil
+}
+
+// Forget drops the records with these IDs, so the next Update reports
+// them as added.
+func (t *Tracker[T]) Forget(ids ...int) {
+	for _, id := range ids {
+		delete(t.seen, id)
+	}
+}
+
+// Update records items as the latest poll and returns what differs from the
+// previous one. On the first call every item is reported as added.
+func (t *Tracker[T]) Update(items []T) Diff[T] {
+	var d Diff[T]
+	next := make(map[int]string, len(items))
+
+	for _, item := range items {
+		id := t.id(item)
+		fp := t.fingerprint(item)
+		next[id] = fp
+
+		prev, ok := t.seen[id]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, item)
+		case prev != fp:
+			d.Changed = append(d.Changed, item)
+		}
+	}
+
+	for id := range t.seen {
+		if _, ok := next[id]; !ok {
+			d.Removed = append(d.Removed, id)
+		}
+	}
+
+	t.seen = next
+	return d
+}
+
+// Conversations tracks conversations by the fields agents care about:
+// status, activity, assignment, priority and labels.
+func Conversations() *Tracker[sdk.Conversation] {
+	return NewTracker(
+		func(c sdk.Conversation) int { return c.ID },
+		ConversationFingerprint,
+	)
+}
+
+func ConversationFingerprint(c sdk.Conversation) string {
+	assignee, team, priority := 0, 0, ""
+	if c.Meta.Assignee != nil {
+		assignee = c.Meta.Assignee.ID
+	}
+	if c.Meta.Team != nil {
+		team = c.Meta.Team.ID
+	}
+	if c.Priority != nil {
+		priority = *c.Priority
+	}
+	return fmt.Sprintf("%s|%d|%d|%d|%d|%d|%s|%s",
+		c.Status, c.LastActivityAt, c.MessagesCount, c.UnreadCount,
+		assignee, team, priority, strings.Join(c.Labels, ","))
+}
+
+// Messages tracks messages by content and delivery status.
+func Messages() *Tracker[sdk.Message] {
+	return NewTracker(
+		func(m sdk.Message) int { return m.ID },
+		func(m sdk.Message) string { return m.Status + "|" + m.Content },
+	)
+}
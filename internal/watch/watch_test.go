@@ -0,0 +1,96 @@
+package watch
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+func ids(convs []sdk.Conversation) []int {
+	var out []int
+	for _, c := range convs {
+		out = append(out, c.ID)
+	}
+	return out
+}
+
+func TestTrackerUpdate(t *testing.T) {
+	open := func(id int, activity int64) sdk.Conversation {
+		return sdk.Conversation{ID: id, Status: "open", LastActivityAt: activity}
+	}
+
+	tests := []struct {
+		name        string
+		forget      []int
+		poll        []sdk.Conversation
+		wantAdded   []int
+		wantChanged []int
+		wantRemoved []int
+	}{
+		{"first poll adds everything", nil, []sdk.Conversation{open(1, 10), open(2, 10)}, []int{1, 2}, nil, nil},
+		{"unchanged", nil, []sdk.Conversation{open(1, 10), open(2, 10)}, nil, nil, nil},
+		{"new activity", nil, []sdk.Conversation{open(1, 20), open(2, 10)}, nil, []int{1}, nil},
+		{"added and removed", nil, []sdk.Conversation{open(1, 20), open(3, 10)}, []int{3}, nil, []int{2}},
+		{"forgotten is added again", []int{1}, []sdk.Conversation{open(1, 20), open(3, 10)}, []int{1}, nil, nil},
+		{"empty poll removes all", nil, nil, nil, nil, []int{1, 3}},
+	}
+
+	tr := Conversations()
+	if tr.Primed() {
+		t.Fatal("new tracker is primed")
+	}
+	for _, tt := range tests {
+		tr.Forget(tt.forget...)
+		d := tr.Update(tt.poll)
+		slices.Sort(d.Removed)
+		if !reflect.DeepEqual(ids(d.Added), tt.wantAdded) ||
+			!reflect.DeepEqual(ids(d.Changed), tt.wantChanged) ||
+			!reflect.DeepEqual(d.Removed, tt.wantRemoved) {
+			t.Errorf("%s: got added %v changed %v removed %v, want %v %v %v", tt.name,
+				ids(d.Added), ids(d.Changed), d.Removed, tt.wantAdded, tt.wantChanged, tt.wantRemoved)
+		}
+		if empty := tt.wantAdded == nil && tt.wantChanged == nil && tt.wantRemoved == nil; d.Empty() != empty {
+			t.Errorf("%s: Empty() = %v", tt.name, d.Empty())
+		}
+	}
+
+	tr.Reset()
+	if tr.Primed() {
+		t.Error("tracker still primed after Reset")
+	}
+	if d := tr.Update([]sdk.Conversation{open(1, 20)}); !reflect.DeepEqual(ids(d.Added), []int{1}) {
+		t.Errorf("after Reset: got added %v, want [1]", ids(d.Added))
+	}
+}
+
+func TestConversationFingerprint(t *testing.T) {
+	high := "high"
+	base := sdk.Conversation{ID: 1, Status: "open", LastActivityAt: 10, Labels: []string{"a"}}
+
+	tests := []struct {
+		name   string
+		edit   func(c *sdk.Conversation)
+		change bool
+	}{
+		{"status", func(c *sdk.Conversation) { c.Status = "resolved" }, true},
+		{"activity", func(c *sdk.Conversation) { c.LastActivityAt = 11 }, true},
+		{"unread", func(c *sdk.Conversation) { c.UnreadCount = 2 }, true},
+		{"assignee", func(c *sdk.Conversation) { c.Meta.Assignee = &sdk.Agent{ID: 3} }, true},
+		{"team", func(c *sdk.Conversation) { c.Meta.Team = &sdk.Team{ID: 4} }, true},
+		{"priority", func(c *sdk.Conversation) { c.Priority = &high }, true},
+		{"labels", func(c *sdk.Conversation) { c.Labels = []string{"a", "b"} }, true},
+		{"assignee name only", func(c *sdk.Conversation) { c.Meta.Assignee = &sdk.Agent{Name: "x"} }, false},
+		{"contact", func(c *sdk.Conversation) { c.Meta.Sender = &sdk.Contact{ID: 9} }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base
+			tt.edit(&c)
+			if got := ConversationFingerprint(c) != ConversationFingerprint(base); got != tt.change {
+				t.Errorf("fingerprint changed = %v, want %v", got, tt.change)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package webhook receives Chatwoot webhook deliveries and decodes them into
+// the SDK's Conversation and Message types.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chatwoot/chatwoot-cli/internal/sdk"
+)
+
+const (
+	maxBodySize = 5 << 20
+
+	// signatureTolerance bounds how old a signed delivery may be, so a
+	// captured request can't be replayed later.
+	signatureTolerance = 5 * time.Minute
+)
+
+// Event is a decoded webhook delivery. Conversation and Message are set
+// when the payload carries them; Payload always holds the original body.
+type Event struct {
+	Name         string            `json:"event"`
+	ReceivedAt   time.Time         `json:"received_at"`
+	Conversation *sdk.Conversation `json:"conversation,omitempty"`
+	Message      *sdk.Message      `json:"message,omitempty"`
+	Payload      json.RawMessage   `json:"payload"`
+}
+
+// Decode parses a webhook body. Webhook payloads differ from the REST API
+// (string message types, ISO timestamps), so they are normalized first.
+func Decode(body []byte) (*Event, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	name, _ := raw["event"].(string)
+	if name == "" {
+		return nil, errors.New("webhook payload has no event name")
+	}
+	ev := &Event{Name: name, ReceivedAt: time.Now(), Payload: body}
+
+	switch {
+	case strings.HasPrefix(name, "message_"):
+		msg := new(sdk.Message)
+		if err := decodeInto(raw, msg); err != nil {
+			return nil, err
+		}
+		ev.Message = msg
+		if conv, ok := raw["conversation"].(map[string]interface{}); ok {
+			ev.Conversation = new(sdk.Conversation)
+			if err := decodeInto(conv, ev.Conversation); err != nil {
+				return nil, err
+			}
+			if msg.ConversationID == 0 {
+				msg.ConversationID = ev.Conversation.ID
+			}
+		}
+
+	case strings.HasPrefix(name, "conversation_typing"):
+		if conv, ok := raw["conversation"].(map[string]interface{}); ok {
+			ev.Conversation = new(sdk.Conversation)
+			if err := decodeInto(conv, ev.Conversation); err != nil {
+				return nil, err
+			}
+		}
+
+	case strings.HasPrefix(name, "conversation_"), name == "webwidget_triggered":
+		ev.Conversation = new(sdk.Conversation)
+		if err := decodeInto(raw, ev.Conversation); err != nil {
+			return nil, err
+		}
+	}
+
+	return ev, nil
+}
+
+// decodeInto normalizes obj and decodes it into v.
+func decodeInto(obj map[string]interface{}, v interface{}) error {
+	normalize(obj)
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	return nil
+}
+
+var timeKeys = []string{
+	"created_at", "updated_at", "timestamp", "last_activity_at",
+	"agent_last_seen_at", "contact_last_seen_at", "waiting_since",
+}
+
+var messageTypes = map[string]int{"incoming": 0, "outgoing": 1, "activity": 2, "template": 3}
+
+// normalize converts webhook-style fields to the REST representation the
+// SDK types expect, recursing into nested messages.
+func normalize(obj map[string]interface{}) {
+	for _, key := range timeKeys {
+		s, ok := obj[key].(string)
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			obj[key] = t.Unix()
+		} else if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			obj[key] = n
+		} else {
+			delete(obj, key)
+		}
+	}
+
+	if s, ok := obj["message_type"].(string); ok {
+		obj["message_type"] = messageTypes[s]
+	}
+
+	if msgs, ok := obj["messages"].([]interface{}); ok {
+		for _, m := range msgs {
+			if mm, ok := m.(map[string]interface{}); ok {
+				normalize(mm)
+			}
+		}
+	}
+}
+
+// Verify checks a delivery signed with the webhook secret. Chatwoot sends
+// X-Chatwoot-Timestamp and X-Chatwoot-Signature ("sha256=<hex>"), where the
+// signature is an HMAC-SHA256 of "<timestamp>.<body>".
+func Verify(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Chatwoot-Timestamp")
+	sig := strings.TrimPrefix(header.Get("X-Chatwoot-Signature"), "sha256=")
+	if ts == "" || sig == "" {
+		return errors.New("missing signature headers")
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("invalid signature timestamp")
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > signatureTolerance || d < -signatureTolerance {
+		return errors.New("signature timestamp outside tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// Handler returns an http.Handler that verifies (when secret is set) and
+// decodes each delivery before passing it to fn. Invalid deliveries are
+// rejected so Chatwoot records the failure.
+func Handler(secret string, fn func(*Event)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if secret != "" {
+			if err := Verify(secret, r.Header, body, time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		ev, err := Decode(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fn(ev)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(secret, ts, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := `{"event":"message_created"}`
+
+	tests := []struct {
+		name    string
+		ts      string
+		sig     string
+		body    string
+		wantErr string
+	}{
+		{"valid", ts, sign("s3cret", ts, body), body, ""},
+		{"valid without prefix", ts, strings.TrimPrefix(sign("s3cret", ts, body), "sha256="), body, ""},
+		{"wrong secret", ts, sign("other", ts, body), body, "signature mismatch"},
+		{"tampered body", ts, sign("s3cret", ts, body), body + " ", "signature mismatch"},
+		{"missing signature", ts, "", body, "missing signature headers"},
+		{"missing timestamp", "", sign("s3cret", ts, body), body, "missing signature headers"},
+		{"bad timestamp", "soon", sign("s3cret", "soon", body), body, "invalid signature timestamp"},
+		{"too old", "1699999000", sign("s3cret", "1699999000", body), body, "outside tolerance"},
+		{"in the future", "1700001000", sign("s3cret", "1700001000", body), body, "outside tolerance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.ts != "" {
+				h.Set("X-Chatwoot-Timestamp", tt.ts)
+			}
+			if tt.sig != "" {
+				h.Set("X-Chatwoot-Signature", tt.sig)
+			}
+			err := Verify("s3cret", h, []byte(tt.body), now)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Verify() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Verify() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantErr  bool
+		wantConv int
+		wantMsg  int
+		check    func(t *testing.T, ev *Event)
+	}{
+		{
+			name:     "message created",
+			body:     `{"event":"message_created","id":9,"content":"hi","message_type":"outgoing","created_at":"2024-01-02T03:04:05Z","conversation":{"id":4,"status":"open"}}`,
+			wantConv: 4,
+			wantMsg:  9,
+			check: func(t *testing.T, ev *Event) {
+				if ev.Message.MessageType != 1 {
+					t.Errorf("message_type = %d, want 1", ev.Message.MessageType)
+				}
+				if ev.Message.CreatedAt != 1704164645 {
+					t.Errorf("created_at = %d, want 1704164645", ev.Message.CreatedAt)
+				}
+				if ev.Message.ConversationID != 4 {
+					t.Errorf("conversation_id = %d, want 4", ev.Message.ConversationID)
+				}
+			},
+		},
+		{
+			name:     "conversation status changed",
+			body:     `{"event":"conversation_status_changed","id":4,"status":"resolved","messages":[{"id":1,"message_type":"incoming","created_at":"1700000000"}]}`,
+			wantConv: 4,
+			check: func(t *testing.T, ev *Event) {
+				if ev.Conversation.Status != "resolved" {
+					t.Errorf("status = %q, want resolved", ev.Conversation.Status)
+				}
+			},
+		},
+		{
+			name:     "typing",
+			body:     `{"event":"conversation_typing_on","conversation":{"id":4},"user":{"name":"Ann"}}`,
+			wantConv: 4,
+		},
+		{
+			name: "unknown event keeps payload",
+			body: `{"event":"contact_created","id":1}`,
+			check: func(t *testing.T, ev *Event) {
+				if string(ev.Payload) != `{"event":"contact_created","id":1}` {
+					t.Errorf("payload = %s", ev.Payload)
+				}
+			},
+		},
+		{name: "bad time is dropped", body: `{"event":"message_created","id":2,"created_at":"yesterday"}`, wantMsg: 2},
+		{name: "no event", body: `{"id":1}`, wantErr: true},
+		{name: "not json", body: `event=message_created`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := Decode([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Decode() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() = %v", err)
+			}
+			convID, msgID := 0, 0
+			if ev.Conversation != nil {
+				convID = ev.Conversation.ID
+			}
+			if ev.Message != nil {
+				msgID = ev.Message.ID
+			}
+			if convID != tt.wantConv || msgID != tt.wantMsg {
+				t.Errorf("conversation %d, message %d; want %d, %d", convID, msgID, tt.wantConv, tt.wantMsg)
+			}
+			if tt.check != nil {
+				tt.check(t, ev)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	body := `{"event":"conversation_created","id":3}`
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name   string
+		secret string
+		method string
+		sig    string
+		body   string
+		want   int
+	}{
+		{"unsigned", "", http.MethodPost, "", body, http.StatusNoContent},
+		{"signed", "s3cret", http.MethodPost, sign("s3cret", ts, body), body, http.StatusNoContent},
+		{"bad signature", "s3cret", http.MethodPost, sign("nope", ts, body), body, http.StatusUnauthorized},
+		{"get", "", http.MethodGet, "", "", http.StatusMethodNotAllowed},
+		{"invalid payload", "", http.MethodPost, "", `{}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Event
+			h := Handler(tt.secret, func(ev *Event) { got = ev })
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			req.Header.Set("X-Chatwoot-Timestamp", ts)
+			if tt.sig != "" {
+				req.Header.Set("X-Chatwoot-Signature", tt.sig)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if (got != nil) != (tt.want == http.StatusNoContent) {
+				t.Errorf("handler called = %v", got != nil)
+			}
+		})
+	}
+}